@@ -1,6 +1,6 @@
 module github.com/daimonaslabs/go-ubus-rpc
 
-go 1.23.4
+go 1.24
 
 // local replaces which should only be used for development purposes
 replace github.com/daimonaslabs/go-ubus-rpc => ./go-ubus-rpc
@@ -8,6 +8,7 @@ replace github.com/daimonaslabs/go-ubus-rpc => ./go-ubus-rpc
 require (
 	github.com/ethereum/go-ethereum v1.15.7
 	github.com/spf13/cobra v1.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,206 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// stored in metadata.json of backup archives. it covers the layout of the archive, i.e. the file
+// names and the metadata, the config files hold their sections like a snapshot does. ReadBackup
+// refuses archives of a newer version
+const BackupVersion = 1
+
+const (
+	backupMetadataFile = "metadata.json"
+	backupConfigDir    = "configs"
+)
+
+// describes where and when a backup was taken
+type BackupMetadata struct {
+	Version int               `json:"version"`
+	Created time.Time         `json:"created"`
+	Board   SystemBoardResult `json:"board"`
+}
+
+// every config of a router, see TakeBackup. stored as a tar.gz archive with the metadata in
+// metadata.json and each config in configs/<config>.json
+type Backup struct {
+	Metadata BackupMetadata
+	Configs  map[string]UCIGetResult
+}
+
+// reads the board info and every config `uci configs` lists. staged changes of this session are
+// included
+func TakeBackup(ctx context.Context, rpc *UbusRPC) (b Backup, err error) {
+	boardOpts := SystemBoardOptions{}
+	response, err := rpc.System().Board(ctx, boardOpts)
+	if err != nil {
+		return b, err
+	}
+	board, err := boardOpts.GetResult(response)
+	if err != nil {
+		return b, err
+	}
+
+	snapshot, err := TakeSnapshot(ctx, rpc)
+	if err != nil {
+		return b, err
+	}
+
+	b.Metadata = BackupMetadata{Version: BackupVersion, Created: snapshot.Created, Board: board}
+	b.Configs = snapshot.Configs
+	return b, nil
+}
+
+// writes the backup as a tar.gz archive
+func (b Backup) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	writeFile := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		header := &tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: b.Metadata.Created}
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	metadata := b.Metadata
+	metadata.Version = BackupVersion
+	if err := writeFile(backupMetadataFile, metadata); err != nil {
+		return err
+	}
+
+	configs := make([]string, 0, len(b.Configs))
+	for config := range b.Configs {
+		configs = append(configs, config)
+	}
+	sort.Strings(configs)
+	for _, config := range configs {
+		sections, err := marshalSections(config, b.Configs[config])
+		if err != nil {
+			return err
+		}
+		if err = writeFile(path.Join(backupConfigDir, config+".json"), sections); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// reads a backup written by Backup.Write
+func ReadBackup(r io.Reader) (b Backup, err error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return b, err
+	}
+	defer gz.Close()
+
+	b.Configs = make(map[string]UCIGetResult)
+	foundMetadata := false
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return b, err
+		}
+
+		switch dir, file := path.Split(header.Name); {
+		case header.Name == backupMetadataFile:
+			if err = json.NewDecoder(tr).Decode(&b.Metadata); err != nil {
+				return b, fmt.Errorf("%s: %w", header.Name, err)
+			} else if b.Metadata.Version < 1 || b.Metadata.Version > BackupVersion {
+				return b, fmt.Errorf("unsupported backup version %d", b.Metadata.Version)
+			}
+			foundMetadata = true
+		case dir == backupConfigDir+"/" && strings.HasSuffix(file, ".json"):
+			config := strings.TrimSuffix(file, ".json")
+			var raw []json.RawMessage
+			if err = json.NewDecoder(tr).Decode(&raw); err != nil {
+				return b, fmt.Errorf("%s: %w", header.Name, err)
+			}
+			if b.Configs[config], err = unmarshalSections(config, raw); err != nil {
+				return b, err
+			}
+		}
+	}
+
+	if !foundMetadata {
+		return b, fmt.Errorf("not a backup, %s is missing", backupMetadataFile)
+	}
+	return b, nil
+}
+
+// returns the calls which restore the given configs of the backup, or all of them if there are
+// none. every restored config is pruned to exactly the state in the backup. configs which do not
+// exist on the router cannot be created through rpcd, they are skipped and returned
+func (b Backup) Plan(ctx context.Context, rpc *UbusRPC, configs ...string) (plan UCIPlan, skipped []string, err error) {
+	if len(configs) == 0 {
+		for config := range b.Configs {
+			configs = append(configs, config)
+		}
+	}
+	sort.Strings(configs)
+
+	configsOpts := UCIConfigsOptions{}
+	response, err := rpc.UCI().Configs(ctx, configsOpts)
+	if err != nil {
+		return plan, nil, err
+	}
+	live, err := configsOpts.GetResult(response)
+	if err != nil {
+		return plan, nil, err
+	}
+
+	var desired []DesiredConfig
+	for _, config := range configs {
+		result, ok := b.Configs[config]
+		if !ok {
+			return plan, nil, fmt.Errorf("config %s is not in the backup", config)
+		} else if !slices.Contains(live.Configs, config) {
+			skipped = append(skipped, config)
+			continue
+		}
+		desired = append(desired, DesiredConfig{Config: config, Sections: result.Sections, Prune: true})
+	}
+
+	plan, err = Plan(ctx, rpc, desired...)
+	return plan, skipped, err
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestBackupRoundTrip(t *testing.T) {
+	b := Backup{
+		Metadata: BackupMetadata{
+			Created: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+			Board:   SystemBoardResult{Hostname: "OpenWrt", BoardName: "glinet,gl-mt6000"},
+		},
+		Configs: map[string]UCIGetResult{
+			"firewall": currentResult(t),
+			// a config without a registered model
+			"custom": {Sections: []uci.ConfigSection{
+				genericSection(t, `{".anonymous":false,".type":"thing",".name":"b","value":"1"}`),
+				genericSection(t, `{".anonymous":true,".type":"thing",".name":"cfg02c8f1","list":["x","y"]}`),
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	actual, err := ReadBackup(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.Metadata.Version = BackupVersion
+	if !reflect.DeepEqual(actual.Metadata, b.Metadata) {
+		t.Error("\nexpected: ", b.Metadata, "\nactual: ", actual.Metadata)
+	}
+	if !reflect.DeepEqual(actual.Configs, b.Configs) {
+		t.Error("\nexpected: ", b.Configs, "\nactual: ", actual.Configs)
+	}
+}
+
+func TestReadBackupErrors(t *testing.T) {
+	archive := func(files map[string]string) *bytes.Buffer {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for name, data := range files {
+			if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(data))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(data)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return &buf
+	}
+
+	for name, r := range map[string]*bytes.Buffer{
+		"not gzip":         bytes.NewBufferString("metadata.json"),
+		"missing metadata": archive(map[string]string{"configs/network.json": "[]"}),
+		"newer version":    archive(map[string]string{"metadata.json": `{"version":99}`}),
+		"invalid config":   archive(map[string]string{"metadata.json": `{"version":1}`, "configs/network.json": "{"}),
+	} {
+		if _, err := ReadBackup(r); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
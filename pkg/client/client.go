@@ -54,6 +54,7 @@ type UbusRPC struct {
 	Call
 	clientset
 	sessionRPC
+	systemRPC
 	uciRPC
 }
 
@@ -61,6 +62,10 @@ func (u *UbusRPC) Session() SessionInterface {
 	return newSessionRPC(u)
 }
 
+func (u *UbusRPC) System() SystemInterface {
+	return newSystemRPC(u)
+}
+
 func (u *UbusRPC) UCI() UCIInterface {
 	return newUCIRPC(u)
 }
@@ -170,6 +175,17 @@ func (u *UbusRPC) Load() (string, error) {
 	return configFilePath, nil
 }
 
+// loads the client saved by `gur login` and adds it to ctx, see AddToContext. meant for the PreRun
+// of commands which talk to the router, it exits if there is no saved client
+func LoadToContext(ctx context.Context) context.Context {
+	rpc := UbusRPC{}
+	configFile, err := rpc.Load()
+	if err != nil {
+		log.Fatalln(configFile, "not found, you should run `gur login`!")
+	}
+	return AddToContext(ctx, rpc)
+}
+
 type CtxKey string
 
 func AddToContext(ctx context.Context, u UbusRPC) context.Context {
@@ -82,7 +82,7 @@ func TestUCIAddSetDelete(t *testing.T) {
 	checkErr(t, err)
 
 	forwardingSectionOptions := firewall.ForwardingSectionOptions{
-		Enabled: uci.BoolPtr(true),
+		Enabled: uci.Some[uci.Bool](true),
 	}
 	uciSetOpts := UCISetOptions{Config: firewall.Config, Section: addResult.Section, Values: forwardingSectionOptions}
 	_, err = rpc.UCI().Set(ctx, uciSetOpts)
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/session"
+)
+
+// a ubus call received by newFakeRPC
+type fakeCall struct {
+	Path      string
+	Procedure string
+	Signature json.RawMessage
+}
+
+// returns a client for a fake ubus endpoint which answers every call with the JSON handle returns
+// for it, e.g. `[0,{"values":{}}]`, instead of a router. the calls are recorded in order
+func newFakeRPC(t *testing.T, handle func(call fakeCall) string) (*UbusRPC, *[]fakeCall) {
+	t.Helper()
+	var calls []fakeCall
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage   `json:"id"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Params) != 4 {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		var call fakeCall
+		json.Unmarshal(request.Params[1], &call.Path)
+		json.Unmarshal(request.Params[2], &call.Procedure)
+		call.Signature = request.Params[3]
+		calls = append(calls, call)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, request.ID, handle(call))
+	}))
+	t.Cleanup(server.Close)
+
+	rpcClient, err := newRPCClient(context.Background(), server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(rpcClient.Close)
+
+	return &UbusRPC{clientset: clientset{RPCClient: rpcClient, UbusSession: &session.Session{}, URL: server.URL}}, &calls
+}
+
+// the procedures of calls in order, e.g. "uci.get"
+func procedures(calls []fakeCall) []string {
+	names := make([]string, len(calls))
+	for i, call := range calls {
+		names[i] = call.Path + "." + call.Procedure
+	}
+	return names
+}
@@ -86,6 +86,21 @@ func (r Response) MarshalJSON() ([]byte, error) {
 // always the first object of the Response tuple
 type ExitCode int
 
+// ubus status codes, mirrors enum ubus_msg_status in libubus
+const (
+	ExitCodeOK ExitCode = iota
+	ExitCodeInvalidCommand
+	ExitCodeInvalidArgument
+	ExitCodeMethodNotFound
+	ExitCodeNotFound
+	ExitCodeNoData
+	ExitCodePermissionDenied
+	ExitCodeTimeout
+	ExitCodeNotSupported
+	ExitCodeUnknownError
+	ExitCodeConnectionFailed
+)
+
 func (e ExitCode) isResultObject() {}
 
 func (e ExitCode) Error() string {
@@ -120,6 +135,7 @@ func registerResultObjectMatcher(checker resultObjectMatcher) {
 func init() {
 	registerResultObjectMatcher(matchExitCode)
 	registerResultObjectMatcher(matchAddResult)
+	registerResultObjectMatcher(matchBoardResult)
 	registerResultObjectMatcher(matchChangesResult)
 	registerResultObjectMatcher(matchConfigsResult)
 	registerResultObjectMatcher(matchSessionResult)
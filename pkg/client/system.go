@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+type SystemInterface interface {
+	Board(ctx context.Context, opts SystemBoardOptions) (r Response, err error)
+}
+
+// implements SystemInterface
+type systemRPC struct {
+	*UbusRPC
+}
+
+func newSystemRPC(u *UbusRPC) *systemRPC {
+	u.Call.setPath("system")
+	return &systemRPC{u}
+}
+
+func (c *systemRPC) Board(ctx context.Context, opts SystemBoardOptions) (Response, error) {
+	c.setProcedure("board")
+	c.setSignature(opts)
+
+	return c.do(ctx)
+}
+
+/*
+################################################################
+#
+# all xOptions types are in this block. they all implement the
+# Signature interface.
+#
+################################################################
+*/
+
+// implements Signature interface
+// empty struct because there are no options but it has a special return type so we're
+// following the same pattern as the other commands to get the result
+type SystemBoardOptions struct{}
+
+func (SystemBoardOptions) isOptsType() {}
+
+func (opts SystemBoardOptions) GetResult(p Response) (u SystemBoardResult, err error) {
+	if len(p) > 1 {
+		switch obj := p[1].(type) {
+		case boardResult:
+			return obj.SystemBoardResult, nil
+		default:
+			return u, errors.New("not a SystemBoardResult")
+		}
+	} else if len(p) == 1 { // error
+		return u, errors.New(p[0].(ExitCode).Error())
+	}
+	return u, errors.New("empty response")
+}
+
+/*
+################################################################
+#
+# all exported XResult types are in this block.
+#
+################################################################
+*/
+
+// result of a `system board` command
+type SystemBoardResult struct {
+	Kernel     string        `json:"kernel,omitempty"`
+	Hostname   string        `json:"hostname,omitempty"`
+	System     string        `json:"system,omitempty"`
+	Model      string        `json:"model,omitempty"`
+	BoardName  string        `json:"board_name,omitempty"`
+	RootFSType string        `json:"rootfs_type,omitempty"`
+	Release    SystemRelease `json:"release,omitzero"`
+}
+
+// the firmware the router runs, from /etc/openwrt_release
+type SystemRelease struct {
+	Distribution string `json:"distribution,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Revision     string `json:"revision,omitempty"`
+	Target       string `json:"target,omitempty"`
+	Description  string `json:"description,omitempty"`
+	BuildDate    string `json:"builddate,omitempty"`
+}
+
+/*
+################################################################
+#
+# all unexported xResult types are in this block.
+#
+################################################################
+*/
+
+// implements ResultObject interface
+// used for handling the raw RPC response
+type boardResult struct {
+	SystemBoardResult
+}
+
+func (boardResult) isResultObject() {}
+
+/*
+################################################################
+#
+# all matchXResult funcs are in this block. used in init().
+#
+################################################################
+*/
+
+// matcher for boardResult
+func matchBoardResult(data json.RawMessage) (ResultObject, error) {
+	var val SystemBoardResult
+
+	if err := json.Unmarshal(data, &val); err == nil {
+		if val.BoardName != "" {
+			return boardResult{val}, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -53,11 +53,10 @@ func (c *Call) setProcedure(p string) {
 	c.Procedure = p
 }
 
+// sig is sent as is, it only has to marshal
 func (uc *Call) setSignature(sig Signature) {
-	data, err := json.Marshal(sig)
-	if err != nil {
+	if _, err := json.Marshal(sig); err != nil {
 		panic(err)
 	}
 	uc.Signature = sig
-	json.Unmarshal(data, &uc.Signature)
 }
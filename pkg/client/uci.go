@@ -17,30 +17,50 @@ limitations under the License.
 package client
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"sort"
+	"strconv"
 
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dhcp"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dropbear"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/system"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/uhttpd"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/wireless"
+	// the stock configs register their typed sections with uci on import
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dhcp"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dropbear"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/luci"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/rpcd"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/system"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/ubootenv"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/ucitrack"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/uhttpd"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/wireless"
+	// as do the models of popular add-on packages
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/ddns"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/mwan3"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/openvpn"
+	_ "github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/sqm"
 )
 
 type UCIInterface interface {
 	Add(ctx context.Context, opts UCIAddOptions) (r Response, err error)
+	AddList(ctx context.Context, opts UCIAddListOptions) (r Response, err error)
 	Apply(ctx context.Context, opts UCIApplyOptions) (r Response, err error)
 	Changes(ctx context.Context, opts UCIChangesOptions) (r Response, err error)
 	Configs(ctx context.Context, opts UCIConfigsOptions) (r Response, err error)
+	Confirm(ctx context.Context, opts UCIConfirmOptions) (r Response, err error)
+	DelList(ctx context.Context, opts UCIDelListOptions) (r Response, err error)
 	Delete(ctx context.Context, opts UCIDeleteOptions) (r Response, err error)
 	Get(ctx context.Context, opts UCIGetOptions) (r Response, err error)
+	Move(ctx context.Context, opts UCIMoveOptions) (r Response, err error)
+	Order(ctx context.Context, opts UCIOrderOptions) (r Response, err error)
 	Revert(ctx context.Context, opts UCIRevertOptions) (r Response, err error)
+	Rollback(ctx context.Context, opts UCIRollbackOptions) (r Response, err error)
 	Set(ctx context.Context, opts UCISetOptions) (r Response, err error)
 }
 
@@ -55,12 +75,27 @@ func newUCIRPC(u *UbusRPC) *uciRPC {
 }
 
 func (c *uciRPC) Add(ctx context.Context, opts UCIAddOptions) (Response, error) {
+	if opts.Values != nil {
+		if err := uci.Validate(opts.Values); err != nil {
+			return nil, err
+		}
+	}
+
 	c.setProcedure("add")
 	c.setSignature(opts)
 
 	return c.do(ctx)
 }
 
+// rpcd has no add_list procedure, so this reads the list and writes it back with the new
+// values appended. the two steps are not atomic: without opts.Expected, values someone else
+// wrote in between are overwritten
+func (c *uciRPC) AddList(ctx context.Context, opts UCIAddListOptions) (Response, error) {
+	return c.modifyList(ctx, opts.Config, opts.Section, opts.Option, opts.Expected, func(list uci.List) uci.List {
+		return append(list, opts.Values...)
+	})
+}
+
 func (c *uciRPC) Apply(ctx context.Context, opts UCIApplyOptions) (Response, error) {
 	c.setProcedure("apply")
 	c.setSignature(opts)
@@ -82,6 +117,25 @@ func (c *uciRPC) Configs(ctx context.Context, opts UCIConfigsOptions) (Response,
 	return c.do(ctx)
 }
 
+// confirms changes applied with UCIApplyOptions.Rollback before the timeout runs out
+func (c *uciRPC) Confirm(ctx context.Context, opts UCIConfirmOptions) (Response, error) {
+	c.setProcedure("confirm")
+	c.setSignature(opts)
+
+	return c.do(ctx)
+}
+
+// rpcd has no del_list procedure, so this reads the list and writes it back without every
+// occurrence of the given values. the option is deleted if the list ends up empty. like AddList,
+// values someone else wrote in between are overwritten unless opts.Expected is set
+func (c *uciRPC) DelList(ctx context.Context, opts UCIDelListOptions) (Response, error) {
+	return c.modifyList(ctx, opts.Config, opts.Section, opts.Option, opts.Expected, func(list uci.List) uci.List {
+		return slices.DeleteFunc(list, func(v string) bool {
+			return slices.Contains(opts.Values, v)
+		})
+	})
+}
+
 func (c *uciRPC) Delete(ctx context.Context, opts UCIDeleteOptions) (Response, error) {
 	c.setProcedure("delete")
 	c.setSignature(opts)
@@ -96,6 +150,46 @@ func (c *uciRPC) Get(ctx context.Context, opts UCIGetOptions) (Response, error)
 	return c.do(ctx)
 }
 
+// rpcd can only order all sections at once, so this reads the order of the config and stages it
+// with the section moved, see MoveSection for moving a section next to another one
+func (c *uciRPC) Move(ctx context.Context, opts UCIMoveOptions) (Response, error) {
+	names, err := sectionNames(ctx, c, opts.Config)
+	if err != nil {
+		return nil, err
+	}
+	if names, err = moveNameTo(names, opts.Section, opts.Index); err != nil {
+		return nil, fmt.Errorf("%s: %w", opts.Config, err)
+	}
+
+	return c.Order(ctx, UCIOrderOptions{Config: opts.Config, Sections: names})
+}
+
+// returns the names of the sections of config in order
+func sectionNames(ctx context.Context, u UCIInterface, config string) ([]string, error) {
+	opts := UCIGetOptions{Config: config}
+	response, err := u.Get(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(result.Sections))
+	for _, s := range result.Sections {
+		names = append(names, s.GetName())
+	}
+	return names, nil
+}
+
+func (c *uciRPC) Order(ctx context.Context, opts UCIOrderOptions) (Response, error) {
+	c.setProcedure("order")
+	c.setSignature(opts)
+
+	return c.do(ctx)
+}
+
 func (c *uciRPC) Revert(ctx context.Context, opts UCIRevertOptions) (Response, error) {
 	c.setProcedure("revert")
 	c.setSignature(opts)
@@ -103,13 +197,172 @@ func (c *uciRPC) Revert(ctx context.Context, opts UCIRevertOptions) (Response, e
 	return c.do(ctx)
 }
 
+// rolls back changes applied with UCIApplyOptions.Rollback right away instead of waiting for the
+// timeout to run out
+func (c *uciRPC) Rollback(ctx context.Context, opts UCIRollbackOptions) (Response, error) {
+	c.setProcedure("rollback")
+	c.setSignature(opts)
+
+	return c.do(ctx)
+}
+
+// options in opts.Values which are marked as deleted (see uci.Delete) are removed with a
+// `uci delete` before the remaining values are set. if the set fails the deleted options are
+// set again to their previous values, so nothing stays staged
 func (c *uciRPC) Set(ctx context.Context, opts UCISetOptions) (Response, error) {
+	if opts.Values != nil {
+		if err := uci.Validate(opts.Values); err != nil {
+			return nil, err
+		}
+		if deleted := uci.MarkedDeleted(opts.Values); len(deleted) > 0 {
+			// deleted options marshal to null, leave them out of the `uci set`
+			values, err := withoutDeleted(opts.Values)
+			if err != nil {
+				return nil, err
+			}
+			previous, err := c.rawOptions(ctx, opts.Config, opts.Section, deleted)
+			if err != nil {
+				return nil, err
+			}
+
+			response, err := c.Delete(ctx, UCIDeleteOptions{Config: opts.Config, Section: opts.Section, Options: deleted})
+			if err != nil || len(values.(rawValues)) == 0 {
+				return response, err
+			}
+
+			opts.Values = values
+			response, err = c.set(ctx, opts)
+			if err != nil && len(previous) > 0 {
+				restore := UCISetOptions{Config: opts.Config, Section: opts.Section, Values: previous}
+				if _, restoreErr := c.set(ctx, restore); restoreErr != nil {
+					err = errors.Join(err, restoreErr)
+				}
+			}
+			return response, err
+		}
+	}
+
+	return c.set(ctx, opts)
+}
+
+func (c *uciRPC) set(ctx context.Context, opts UCISetOptions) (Response, error) {
 	c.setProcedure("set")
 	c.setSignature(opts)
 
 	return c.do(ctx)
 }
 
+// returns the given options of a section the way rpcd sent them, so lists stay lists when they
+// are set again. options which are not set are left out
+func (c *uciRPC) rawOptions(ctx context.Context, config, section string, options []string) (rawValues, error) {
+	response, err := c.Get(ctx, UCIGetOptions{Config: config, Section: section})
+	if err != nil {
+		return nil, err
+	} else if len(response) < 2 {
+		return nil, errors.New("empty response")
+	}
+	values, ok := response[1].(valuesResult)
+	if !ok || len(values.Values) != 1 {
+		return nil, errors.New("not a section")
+	}
+
+	var all rawValues
+	for _, data := range values.Values {
+		if err = json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+	}
+	raw := make(rawValues)
+	for _, option := range options {
+		if value, ok := all[option]; ok {
+			raw[option] = value
+		}
+	}
+	return raw, nil
+}
+
+// ErrListConflict is returned by AddList and DelList when the list on the router is not the
+// Expected one, i.e. it was changed by someone else since the caller read it
+var ErrListConflict = errors.New("list was modified concurrently")
+
+// read-modify-write of a list option. the read goes through this session so it already includes
+// any staged changes
+func (c *uciRPC) modifyList(ctx context.Context, config, section, option string, expected *uci.List, modify func(uci.List) uci.List) (Response, error) {
+	current, err := c.getList(ctx, config, section, option)
+	if err != nil {
+		return nil, err
+	}
+
+	list, changed, err := modifiedList(current, expected, modify)
+	if err != nil || !changed {
+		return Response{ExitCodeOK}, err
+	}
+
+	if len(list) == 0 {
+		return c.Delete(ctx, UCIDeleteOptions{Config: config, Section: section, Option: option})
+	}
+	return c.Set(ctx, UCISetOptions{Config: config, Section: section, Values: listValues{option: list}})
+}
+
+// applies modify to a copy of current and reports whether that changed the list. if expected is
+// given and differs from current, ErrListConflict is returned instead
+func modifiedList(current uci.List, expected *uci.List, modify func(uci.List) uci.List) (uci.List, bool, error) {
+	if expected != nil && !slices.Equal(*expected, current) {
+		return nil, false, ErrListConflict
+	}
+
+	list := modify(slices.Clone(current))
+	return list, !slices.Equal(list, current), nil
+}
+
+// returns the current value of a list option, a missing option is an empty list
+func (c *uciRPC) getList(ctx context.Context, config, section, option string) (uci.List, error) {
+	opts := UCIGetOptions{Config: config, Section: section, Option: option}
+	response, err := c.Get(ctx, opts)
+	if len(response) > 0 && response[0] == ExitCodeNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return nil, err
+	}
+	return result.Option[option], nil
+}
+
+// implements uci.ConfigSectionOptions
+// options which have already been marshaled by their typed uci.ConfigSectionOptions
+type rawValues map[string]json.RawMessage
+
+func (rawValues) IsConfigSectionOptions() {}
+
+func withoutDeleted(o uci.ConfigSectionOptions) (uci.ConfigSectionOptions, error) {
+	var values rawValues
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		if string(value) == "null" {
+			delete(values, name)
+		}
+	}
+
+	return values, nil
+}
+
+// implements uci.ConfigSectionOptions
+// always marshals values as JSON arrays so rpcd stores them as a `list`, even with one item
+type listValues map[string][]string
+
+func (listValues) IsConfigSectionOptions() {}
+
 /*
 ################################################################
 #
@@ -123,6 +376,10 @@ func (c *uciRPC) Set(ctx context.Context, opts UCISetOptions) (Response, error)
 type UCIAddOptions struct {
 	Config string `json:"config,omitempty"`
 	Type   string `json:"type,omitempty"`
+	// if set, the new section is created as a named section instead of an anonymous one
+	Name string `json:"name,omitempty"`
+	// initial option values for the new section, saves a separate `uci set` call
+	Values uci.ConfigSectionOptions `json:"values,omitempty"`
 }
 
 func (UCIAddOptions) isOptsType() {}
@@ -130,6 +387,13 @@ func (UCIAddOptions) isOptsType() {}
 func (opts UCIAddOptions) GetResult(p Response) (u UCIAddResult, err error) {
 	if len(p) == 0 {
 		return u, errors.New("empty response")
+	}
+	code, ok := p[0].(ExitCode)
+	if !ok {
+		return u, errors.New("not a UCIAddResult")
+	} else if len(p) == 1 && code == ExitCodeOK && opts.Name != "" {
+		// named sections are created with the requested name
+		u.Section = opts.Name
 	} else if len(p) > 1 {
 		data, _ := json.Marshal(p[1])
 		switch p[1].(type) {
@@ -139,11 +403,25 @@ func (opts UCIAddOptions) GetResult(p Response) (u UCIAddResult, err error) {
 			return u, errors.New("not a UCIAddResult")
 		}
 	} else { // error
-		return u, errors.New(p[0].(ExitCode).Error())
+		return u, errors.New(code.Error())
 	}
 	return u, err
 }
 
+// options of AddList. rpcd has no such procedure, so they are never sent as is, AddList turns
+// them into a `uci set` of the whole list
+type UCIAddListOptions struct {
+	Config  string   `json:"config,omitempty"`
+	Section string   `json:"section,omitempty"`
+	Option  string   `json:"option,omitempty"`
+	Values  []string `json:"values,omitempty"`
+	// the list the change is based on, e.g. from an earlier `uci get`. if set and the list on the
+	// router differs, nothing is written and ErrListConflict is returned
+	Expected *uci.List `json:"-"`
+}
+
+func (UCIAddListOptions) isOptsType() {}
+
 // does not have a GetResult func because this command only returns the exit code
 // implements Signature interface
 type UCIApplyOptions struct {
@@ -192,7 +470,15 @@ func exportRawChanges(changes []change) (Changes []Change) {
 		C.Procedure = c[0]
 		C.Section = c[1]
 		if len(c) == 3 {
-			C.Type = c[2]
+			// the third item depends on the procedure
+			switch C.Procedure {
+			case ChangeAdd, ChangeSet:
+				C.Type = c[2]
+			case ChangeRemove:
+				C.Option = c[2]
+			default: // rename, order
+				C.Value = c[2]
+			}
 		} else if len(c) == 4 {
 			C.Option = c[2]
 			C.Value = c[3]
@@ -228,6 +514,24 @@ func (opts UCIConfigsOptions) GetResult(p Response) (u UCIConfigsResult, err err
 	return u, err
 }
 
+// does not have a GetResult func because this command only returns the exit code
+// implements Signature interface
+type UCIConfirmOptions struct{}
+
+func (UCIConfirmOptions) isOptsType() {}
+
+// options of DelList, never sent as is either, see UCIAddListOptions
+type UCIDelListOptions struct {
+	Config  string   `json:"config,omitempty"`
+	Section string   `json:"section,omitempty"`
+	Option  string   `json:"option,omitempty"`
+	Values  []string `json:"values,omitempty"`
+	// see UCIAddListOptions.Expected
+	Expected *uci.List `json:"-"`
+}
+
+func (UCIDelListOptions) isOptsType() {}
+
 // does not have a GetResult func because this command only returns the exit code
 // implements Signature interface
 type UCIDeleteOptions struct {
@@ -235,6 +539,9 @@ type UCIDeleteOptions struct {
 	Section string `json:"section,omitempty"`
 	Type    string `json:"type,omitempty"`
 	Option  string `json:"option,omitempty"`
+	// delete several options of the section at once, see uci.OptionNames and uci.DeletedOptions
+	// for getting these from typed section options
+	Options []string `json:"options,omitempty"`
 }
 
 func (UCIDeleteOptions) isOptsType() {}
@@ -259,79 +566,12 @@ func (opts UCIGetOptions) GetResult(p Response) (u UCIGetResult, err error) {
 		case valueResult:
 			u.Option = map[string]uci.List{opts.Option: obj.Value}
 		case valuesResult:
-			for _, section := range obj.Values {
-				switch s := section.(type) {
-				case dhcp.BootSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.CircuitIDSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.DHCPSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.DnsmasqSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.HostSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.HostRecordSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.MACSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.OdhcpdSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.RelaySection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.RemoteIDSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.SubscrIDSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.TagSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.UserClassSection:
-					u.Sections = append(u.Sections, s)
-				case dhcp.VendorClassSection:
-					u.Sections = append(u.Sections, s)
-				case dropbear.DropbearSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.DefaultsSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.ForwardingSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.IPSetSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.IncludeSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.RedirectSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.RuleSection:
-					u.Sections = append(u.Sections, s)
-				case firewall.ZoneSection:
-					u.Sections = append(u.Sections, s)
-				case network.BridgeVLANSection:
-					u.Sections = append(u.Sections, s)
-				case network.DeviceSection:
-					u.Sections = append(u.Sections, s)
-				case network.GlobalsSection:
-					u.Sections = append(u.Sections, s)
-				case network.InterfaceSection:
-					u.Sections = append(u.Sections, s)
-				case network.SwitchSection:
-					u.Sections = append(u.Sections, s)
-				case network.SwitchPortSection:
-					u.Sections = append(u.Sections, s)
-				case network.SwitchVLANSection:
-					u.Sections = append(u.Sections, s)
-				case system.SystemSection:
-					u.Sections = append(u.Sections, s)
-				case system.TimeserverSection:
-					u.Sections = append(u.Sections, s)
-				case uhttpd.CertSection:
-					u.Sections = append(u.Sections, s)
-				case uhttpd.UHTTPdSection:
-					u.Sections = append(u.Sections, s)
-				case wireless.WifiDeviceSection:
-					u.Sections = append(u.Sections, s)
-				case wireless.WifiIfaceSection:
-					u.Sections = append(u.Sections, s)
+			for _, name := range obj.sectionNames() {
+				section, err := uci.UnmarshalSection(opts.Config, obj.Values[name])
+				if err != nil {
+					return u, err
 				}
+				u.Sections = append(u.Sections, section)
 			}
 		default:
 			return u, errors.New("not a UCIGetResult")
@@ -339,12 +579,35 @@ func (opts UCIGetOptions) GetResult(p Response) (u UCIGetResult, err error) {
 	} else { // error
 		return u, errors.New(p[0].(ExitCode).Error())
 	}
-	sort.Slice(u.Sections, func(i, j int) bool {
+	// stable so sections without an index keep the order rpcd sent them in
+	sort.SliceStable(u.Sections, func(i, j int) bool {
 		return u.Sections[i].GetIndex() < u.Sections[j].GetIndex()
 	})
 	return u, err
 }
 
+// options of Move, never sent as is either, see UCIAddListOptions
+type UCIMoveOptions struct {
+	Config  string `json:"config,omitempty"`
+	Section string `json:"section,omitempty"`
+	// the position the section is moved to, like the value of an order change. the section is
+	// moved to the end if Index is past it
+	Index int `json:"index"`
+}
+
+func (UCIMoveOptions) isOptsType() {}
+
+// does not have a GetResult func because this command only returns the exit code
+// implements Signature interface
+type UCIOrderOptions struct {
+	Config string `json:"config,omitempty"`
+	// the listed sections are moved to the start of the config in this order, the sections which
+	// are not listed follow them in their current order. see MoveSection for moving a single section
+	Sections []string `json:"sections,omitempty"`
+}
+
+func (UCIOrderOptions) isOptsType() {}
+
 // does not have a GetResult func because this command only returns the exit code
 // implements Signature interface
 type UCIRevertOptions struct {
@@ -353,6 +616,12 @@ type UCIRevertOptions struct {
 
 func (UCIRevertOptions) isOptsType() {}
 
+// does not have a GetResult func because this command only returns the exit code
+// implements Signature interface
+type UCIRollbackOptions struct{}
+
+func (UCIRollbackOptions) isOptsType() {}
+
 // does not have a GetResult func because this command only returns the exit code
 // implements Signature interface
 type UCISetOptions struct {
@@ -376,6 +645,18 @@ type UCIAddResult struct {
 	Section string `json:"section,omitempty"`
 }
 
+// values of Change.Procedure, these are the names rpcd uses for the libuci delta commands
+const (
+	ChangeAdd     = "add"
+	ChangeSet     = "set"
+	ChangeRemove  = "remove"
+	ChangeRename  = "rename"
+	ChangeOrder   = "order"
+	ChangeListAdd = "list-add"
+	ChangeListDel = "list-del"
+)
+
+// a single staged change, see uci_changes.go for rendering, inverting and replaying them
 type Change struct {
 	Procedure string `json:"procedure"`
 	Section   string `json:"section"`
@@ -422,6 +703,29 @@ type addResult struct {
 func (addResult) isResultObject() {}
 
 type change []string
+
+// `order` changes carry their index as a number, everything else is a string
+func (c *change) UnmarshalJSON(data []byte) error {
+	var raw []any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = make(change, len(raw))
+	for i, item := range raw {
+		switch v := item.(type) {
+		case string:
+			(*c)[i] = v
+		case float64:
+			(*c)[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return fmt.Errorf("unsupported change item: %v", item)
+		}
+	}
+
+	return nil
+}
+
 type changesResult struct {
 	Many map[string][]change `json:"many,omitempty"`
 	One  []change            `json:"one,omitempty"`
@@ -559,8 +863,14 @@ func (valueResult) isResultObject() {}
 // you can call json.Marshal and Unmarshal on them like normal and it will figure
 // out which one it is for you. if it is a single response like in the second example,
 // it will unmarshal it into the form of the first one but with only that object.
+//
+// the sections are kept as raw JSON because the same section type can mean different
+// things in different configs (e.g. firewall and network both have a "rule" type), so
+// they can only be decoded once the config is known, see UCIGetOptions.GetResult
 type valuesResult struct {
-	Values map[string]uci.ConfigSection `json:"values"`
+	Values map[string]json.RawMessage `json:"values"`
+	// the section names in the order rpcd sent them, which is the order of the config file
+	names []string
 }
 
 func (valuesResult) isResultObject() {}
@@ -569,16 +879,27 @@ func (v valuesResult) MarshalJSON() ([]byte, error) {
 	// handle single unnamed config (e.g., ".type": "forwarding")
 	if len(v.Values) == 1 {
 		for _, section := range v.Values {
-			return json.Marshal(section)
+			return section, nil
 		}
 	}
 
-	// otherwise marshal as map
-	out := make(map[string]interface{})
-	for name, section := range v.Values {
-		out[name] = section
+	// otherwise marshal as map, keeping the original order
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, name := range v.sectionNames() {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(v.Values[name])
 	}
-	return json.Marshal(out)
+	b.WriteByte('}')
+	return b.Bytes(), nil
 }
 
 func (v *valuesResult) UnmarshalJSON(data []byte) (err error) {
@@ -599,127 +920,61 @@ func (v *valuesResult) UnmarshalJSON(data []byte) (err error) {
 	}
 
 	if isSingleValues(result) {
-		section, err := unmarshalRawSection(values)
-		if err != nil {
+		var name string
+		if err := json.Unmarshal(result[".name"], &name); err != nil {
 			return err
 		}
-		v.Values = map[string]uci.ConfigSection{section.GetName(): section}
-		return nil
+		v.Values = map[string]json.RawMessage{name: values}
+		v.names = []string{name}
 	} else {
 		// handle named entries in map
-		v.Values = make(map[string]uci.ConfigSection)
+		v.Values = make(map[string]json.RawMessage)
 		for name, section := range result {
-			section, err := unmarshalRawSection(section)
-			if err != nil {
-				return err
-			}
 			v.Values[name] = section
 		}
+		if v.names, err = objectKeys(values); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func unmarshalRawResult[S uci.ConfigSection](data []byte) (uci.ConfigSection, error) {
-	var s S
-	err := json.Unmarshal(data, &s)
-	return s, err
-}
-
-func unmarshalRawSection(data []byte) (section uci.ConfigSection, err error) {
-	var probe struct {
-		Type string `json:".type"`
+// returns the names of all sections in order. falls back to sorting them by name if the order
+// is unknown, e.g. for a valuesResult which was built by hand
+func (v valuesResult) sectionNames() []string {
+	if len(v.names) == len(v.Values) {
+		return v.names
 	}
-	var rawSection rawMap
-
-	if err = json.Unmarshal(data, &rawSection); err != nil {
-		return nil, err
+	names := make([]string, 0, len(v.Values))
+	for name := range v.Values {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
+}
 
-	sectionBytes, err := json.Marshal(rawSection)
-	if err != nil {
-		return nil, err
-	}
+// returns the keys of the JSON object in data in the order they appear in
+func objectKeys(data []byte) ([]string, error) {
+	var keys []string
 
-	if err = json.Unmarshal(sectionBytes, &probe); err != nil {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil { // {
 		return nil, err
 	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key.(string))
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
 
-	switch probe.Type {
-	case string(dhcp.Boot):
-		section, err = unmarshalRawResult[dhcp.BootSection](data)
-	case string(dhcp.CircuitID):
-		section, err = unmarshalRawResult[dhcp.CircuitIDSection](data)
-	case string(dhcp.DHCP):
-		section, err = unmarshalRawResult[dhcp.DHCPSection](data)
-	case string(dhcp.Dnsmasq):
-		section, err = unmarshalRawResult[dhcp.DnsmasqSection](data)
-	case string(dhcp.Host):
-		section, err = unmarshalRawResult[dhcp.HostSection](data)
-	case string(dhcp.HostRecord):
-		section, err = unmarshalRawResult[dhcp.HostRecordSection](data)
-	case string(dhcp.MAC):
-		section, err = unmarshalRawResult[dhcp.MACSection](data)
-	case string(dhcp.Odhcpd):
-		section, err = unmarshalRawResult[dhcp.OdhcpdSection](data)
-	case string(dhcp.Relay):
-		section, err = unmarshalRawResult[dhcp.RelaySection](data)
-	case string(dhcp.RemoteID):
-		section, err = unmarshalRawResult[dhcp.RemoteIDSection](data)
-	case string(dhcp.SubscrID):
-		section, err = unmarshalRawResult[dhcp.SubscrIDSection](data)
-	case string(dhcp.Tag):
-		section, err = unmarshalRawResult[dhcp.TagSection](data)
-	case string(dhcp.UserClass):
-		section, err = unmarshalRawResult[dhcp.UserClassSection](data)
-	case string(dhcp.VendorClass):
-		section, err = unmarshalRawResult[dhcp.VendorClassSection](data)
-	case string(dropbear.Dropbear):
-		section, err = unmarshalRawResult[dropbear.DropbearSection](data)
-	case string(firewall.Defaults):
-		section, err = unmarshalRawResult[firewall.DefaultsSection](data)
-	case string(firewall.Forwarding):
-		section, err = unmarshalRawResult[firewall.ForwardingSection](data)
-	case string(firewall.IPSet):
-		section, err = unmarshalRawResult[firewall.IPSetSection](data)
-	case string(firewall.Include):
-		section, err = unmarshalRawResult[firewall.IncludeSection](data)
-	case string(firewall.Redirect):
-		section, err = unmarshalRawResult[firewall.RedirectSection](data)
-	case string(firewall.Rule):
-		section, err = unmarshalRawResult[firewall.RuleSection](data)
-	case string(firewall.Zone):
-		section, err = unmarshalRawResult[firewall.ZoneSection](data)
-	case string(network.BridgeVLAN):
-		section, err = unmarshalRawResult[network.BridgeVLANSection](data)
-	case string(network.Device):
-		section, err = unmarshalRawResult[network.DeviceSection](data)
-	case string(network.Globals):
-		section, err = unmarshalRawResult[network.GlobalsSection](data)
-	case string(network.Interface):
-		section, err = unmarshalRawResult[network.InterfaceSection](data)
-	case string(network.Switch):
-		section, err = unmarshalRawResult[network.SwitchSection](data)
-	case string(network.SwitchPort):
-		section, err = unmarshalRawResult[network.SwitchPortSection](data)
-	case string(network.SwitchVLAN):
-		section, err = unmarshalRawResult[network.SwitchVLANSection](data)
-	case string(system.System):
-		section, err = unmarshalRawResult[system.SystemSection](data)
-	case string(system.Timeserver):
-		section, err = unmarshalRawResult[system.TimeserverSection](data)
-	case string(uhttpd.Cert):
-		section, err = unmarshalRawResult[uhttpd.CertSection](data)
-	case string(uhttpd.UHTTPd):
-		section, err = unmarshalRawResult[uhttpd.UHTTPdSection](data)
-	case string(wireless.WifiDevice):
-		section, err = unmarshalRawResult[wireless.WifiDeviceSection](data)
-	case string(wireless.WifiIface):
-		section, err = unmarshalRawResult[wireless.WifiIfaceSection](data)
-	default:
-		return nil, errors.New("invalid config section")
-	}
-	return section, err
+	return keys, nil
 }
 
 // checks if the value of `values` is a single uci.ConfigSection or not
@@ -796,8 +1051,9 @@ func matchValueResult(data json.RawMessage) (ResultObject, error) {
 func matchValuesResult(data json.RawMessage) (ResultObject, error) {
 	var val valuesResult
 
+	// rpcd sends an empty values object for a config without (matching) sections
 	if err := json.Unmarshal(data, &val); err == nil {
-		if len(val.Values) > 0 {
+		if val.Values != nil {
 			return val, nil
 		}
 	}
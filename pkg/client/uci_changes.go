@@ -0,0 +1,442 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+// renders the change the same way `uci changes` does on the command line, e.g.
+//
+//	+cfg0fad58=forwarding
+//	cfg04ad58.enabled='0'
+//	-cfg04ad58.name
+//	lan.dns+='1.1.1.1'
+func (c Change) String() string {
+	return c.format("")
+}
+
+func (c Change) format(config string) string {
+	var prefix, op string
+	path := c.Section
+	if config != "" {
+		path = config + "." + c.Section
+	}
+	if c.Option != "" {
+		path += "." + c.Option
+	}
+
+	switch c.Procedure {
+	case ChangeAdd:
+		prefix = "+"
+	case ChangeRemove:
+		return "-" + path
+	case ChangeRename:
+		prefix = "@"
+	case ChangeOrder:
+		prefix = "^"
+	case ChangeListAdd:
+		op = "+"
+	case ChangeListDel:
+		op = "-"
+	}
+
+	if c.Type != "" {
+		return prefix + path + "=" + c.Type
+	} else if c.Option == "" {
+		return prefix + path + "=" + c.Value
+	}
+	return prefix + path + op + "='" + c.Value + "'"
+}
+
+// renders all changes as a human-readable diff, one change per line, grouped by config
+func (u UCIChangesResult) Diff() string {
+	var b strings.Builder
+
+	for _, config := range u.configs() {
+		for _, c := range u.Changes[config] {
+			b.WriteString(c.format(config))
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
+
+// returns the change set that undoes u. since changes only record the new values, the state of
+// every affected config before the changes were staged has to be passed in (e.g. a `uci get` done
+// before staging anything), keyed by config. options and sections missing from before are
+// assumed not to have existed, so their inverse is a removal
+func (u UCIChangesResult) Inverse(before map[string]UCIGetResult) (inverse UCIChangesResult, err error) {
+	inverse.Changes = make(map[string][]Change)
+
+	for _, config := range u.configs() {
+		state, err := newChangeState(before[config])
+		if err != nil {
+			return inverse, err
+		}
+
+		var groups [][]Change
+		for _, c := range u.Changes[config] {
+			inv, err := state.inverse(c)
+			if err != nil {
+				return inverse, fmt.Errorf("%s: %w", c.format(config), err)
+			}
+			groups = append(groups, inv)
+			state.apply(c)
+		}
+		// undo the last change first, the changes which undo a single change keep their order
+		slices.Reverse(groups)
+		inverse.Changes[config] = slices.Concat(groups...)
+	}
+
+	return inverse, nil
+}
+
+// returns the UCIInterface calls which stage the same changes again, e.g. on another router or
+// after a revert. rename changes cannot be replayed
+func (u UCIChangesResult) Script() (script UCIScript, err error) {
+	for _, config := range u.configs() {
+		for _, c := range u.Changes[config] {
+			call, err := c.call(config)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", c.format(config), err)
+			}
+			script = append(script, call)
+		}
+	}
+
+	return script, nil
+}
+
+func (u UCIChangesResult) configs() []string {
+	configs := make([]string, 0, len(u.Changes))
+	for config := range u.Changes {
+		configs = append(configs, config)
+	}
+	sort.Strings(configs)
+	return configs
+}
+
+func (c Change) call(config string) (UCICall, error) {
+	switch c.Procedure {
+	case ChangeAdd:
+		// anonymous sections get a new name when they are added again
+		return UCICall{Signature: UCIAddOptions{Config: config, Type: c.Type}, Placeholder: c.Section}, nil
+	case ChangeSet:
+		if c.Option == "" {
+			return UCICall{Signature: UCIAddOptions{Config: config, Type: c.Type, Name: c.Section}}, nil
+		}
+		value, err := json.Marshal(c.Value)
+		if err != nil {
+			return UCICall{}, err
+		}
+		return UCICall{Signature: UCISetOptions{Config: config, Section: c.Section, Values: rawValues{c.Option: value}}}, nil
+	case ChangeRemove:
+		return UCICall{Signature: UCIDeleteOptions{Config: config, Section: c.Section, Option: c.Option}}, nil
+	case ChangeListAdd:
+		return UCICall{Signature: UCIAddListOptions{Config: config, Section: c.Section, Option: c.Option, Values: []string{c.Value}}}, nil
+	case ChangeListDel:
+		return UCICall{Signature: UCIDelListOptions{Config: config, Section: c.Section, Option: c.Option, Values: []string{c.Value}}}, nil
+	case ChangeOrder:
+		index, err := strconv.Atoi(c.Value)
+		if err != nil {
+			return UCICall{}, fmt.Errorf("invalid index %q", c.Value)
+		}
+		return UCICall{Signature: UCIMoveOptions{Config: config, Section: c.Section, Index: index}}, nil
+	}
+
+	return UCICall{}, fmt.Errorf("%s changes cannot be replayed", c.Procedure)
+}
+
+// a single UCIInterface call, Signature is one of the UCIxOptions types
+type UCICall struct {
+	Signature Signature `json:"signature"`
+	// only used for anonymous adds, the name the section had when the call was recorded. later
+	// calls of the same UCIScript which refer to it are run against the newly added section instead
+	Placeholder string `json:"placeholder,omitempty"`
+}
+
+func (c UCICall) Do(ctx context.Context, u UCIInterface) (Response, error) {
+	switch opts := c.Signature.(type) {
+	case UCIAddOptions:
+		return u.Add(ctx, opts)
+	case UCIAddListOptions:
+		return u.AddList(ctx, opts)
+	case UCIDelListOptions:
+		return u.DelList(ctx, opts)
+	case UCIDeleteOptions:
+		return u.Delete(ctx, opts)
+	case UCIMoveOptions:
+		return u.Move(ctx, opts)
+	case UCIOrderOptions:
+		return u.Order(ctx, opts)
+	case UCISetOptions:
+		return u.Set(ctx, opts)
+	}
+
+	return nil, fmt.Errorf("unsupported call: %T", c.Signature)
+}
+
+// replaces the section the call refers to if it was renamed
+func (c UCICall) withSections(names map[string]string) UCICall {
+	rename := func(section string) string {
+		if name, ok := names[section]; ok {
+			return name
+		}
+		return section
+	}
+
+	switch opts := c.Signature.(type) {
+	case UCIAddListOptions:
+		opts.Section = rename(opts.Section)
+		c.Signature = opts
+	case UCIDelListOptions:
+		opts.Section = rename(opts.Section)
+		c.Signature = opts
+	case UCIDeleteOptions:
+		opts.Section = rename(opts.Section)
+		c.Signature = opts
+	case UCIMoveOptions:
+		opts.Section = rename(opts.Section)
+		c.Signature = opts
+	case UCIOrderOptions:
+		sections := make([]string, len(opts.Sections))
+		for i, section := range opts.Sections {
+			sections[i] = rename(section)
+		}
+		opts.Sections = sections
+		c.Signature = opts
+	case UCISetOptions:
+		opts.Section = rename(opts.Section)
+		c.Signature = opts
+	}
+
+	return c
+}
+
+// a replayable sequence of UCIInterface calls
+type UCIScript []UCICall
+
+// stages all calls in order, stopping at the first error. nothing is applied
+func (s UCIScript) Run(ctx context.Context, rpc *UbusRPC) error {
+	names := make(map[string]string)
+
+	for _, call := range s {
+		call = call.withSections(names)
+		response, err := call.Do(ctx, rpc.UCI())
+		if err != nil {
+			return err
+		}
+
+		if call.Placeholder != "" {
+			opts := call.Signature.(UCIAddOptions)
+			result, err := opts.GetResult(response)
+			if err != nil {
+				return err
+			}
+			names[call.Placeholder] = result.Section
+		}
+	}
+
+	return nil
+}
+
+// tracks the sections of a config while walking through its changes
+type changeState struct {
+	sections map[string]*changeStateSection
+	// the section names in order
+	order []string
+}
+
+type changeStateSection struct {
+	Type      string
+	Anonymous bool
+	Options   map[string]uci.List
+}
+
+func newChangeState(before UCIGetResult) (changeState, error) {
+	state := changeState{sections: make(map[string]*changeStateSection)}
+
+	for _, section := range before.Sections {
+		options, err := uci.OptionValues(section)
+		if err != nil {
+			return state, err
+		}
+		state.sections[section.GetName()] = &changeStateSection{
+			Type:      section.GetType(),
+			Anonymous: section.IsAnonymous(),
+			Options:   options,
+		}
+		state.order = append(state.order, section.GetName())
+	}
+
+	return state, nil
+}
+
+// returns the changes which undo c, in the order they have to be applied
+func (s changeState) inverse(c Change) ([]Change, error) {
+	section, exists := s.sections[c.Section]
+
+	switch c.Procedure {
+	case ChangeAdd:
+		return []Change{{Procedure: ChangeRemove, Section: c.Section}}, nil
+	case ChangeSet:
+		if c.Option == "" {
+			if exists {
+				return []Change{{Procedure: ChangeSet, Section: c.Section, Type: section.Type}}, nil
+			}
+			return []Change{{Procedure: ChangeRemove, Section: c.Section}}, nil
+		}
+		return s.restoreOption(c.Section, c.Option, false), nil
+	case ChangeRemove:
+		if c.Option != "" {
+			return s.restoreOption(c.Section, c.Option, false), nil
+		} else if !exists {
+			return nil, nil
+		}
+		// anonymous sections are added again, Script runs the later changes against the new name
+		inverse := []Change{{Procedure: ChangeSet, Section: c.Section, Type: section.Type}}
+		if section.Anonymous {
+			inverse[0].Procedure = ChangeAdd
+		}
+		options := make([]string, 0, len(section.Options))
+		for option := range section.Options {
+			options = append(options, option)
+		}
+		sort.Strings(options)
+		for _, option := range options {
+			inverse = append(inverse, s.restoreOption(c.Section, option, false)[1:]...)
+		}
+		// the section is added at the end
+		if index := slices.Index(s.order, c.Section); index < len(s.order)-1 {
+			inverse = append(inverse, Change{Procedure: ChangeOrder, Section: c.Section, Value: strconv.Itoa(index)})
+		}
+		return inverse, nil
+	case ChangeListAdd, ChangeListDel:
+		if c.Procedure == ChangeListDel && (!exists || !slices.Contains(section.Options[c.Option], c.Value)) {
+			return nil, nil
+		}
+		// list-del removes every occurrence of the value and list-add appends, so the whole list
+		// is written again to keep its order
+		return s.restoreOption(c.Section, c.Option, true), nil
+	case ChangeRename:
+		if c.Option != "" {
+			return []Change{{Procedure: ChangeRename, Section: c.Section, Option: c.Value, Value: c.Option}}, nil
+		}
+		return []Change{{Procedure: ChangeRename, Section: c.Value, Value: c.Section}}, nil
+	case ChangeOrder:
+		if _, err := strconv.Atoi(c.Value); err != nil {
+			return nil, fmt.Errorf("invalid index %q", c.Value)
+		}
+		index := slices.Index(s.order, c.Section)
+		if index < 0 {
+			return nil, fmt.Errorf("section %s not found", c.Section)
+		}
+		return []Change{{Procedure: ChangeOrder, Section: c.Section, Value: strconv.Itoa(index)}}, nil
+	}
+
+	return nil, fmt.Errorf("%s changes cannot be inverted", c.Procedure)
+}
+
+// returns the changes which bring the option back to its current state. the first change always
+// removes the option. single values are set as an option unless list is set
+func (s changeState) restoreOption(section, option string, list bool) []Change {
+	restore := []Change{{Procedure: ChangeRemove, Section: section, Option: option}}
+
+	current, ok := s.sections[section]
+	if !ok {
+		return restore
+	}
+	values, ok := current.Options[option]
+	if !ok {
+		return restore
+	} else if len(values) == 1 && !list {
+		return append(restore, Change{Procedure: ChangeSet, Section: section, Option: option, Value: values[0]})
+	}
+	for _, v := range values {
+		restore = append(restore, Change{Procedure: ChangeListAdd, Section: section, Option: option, Value: v})
+	}
+
+	return restore
+}
+
+func (s *changeState) apply(c Change) {
+	section, exists := s.sections[c.Section]
+	if !exists && c.Procedure != ChangeAdd && c.Procedure != ChangeSet {
+		return
+	} else if !exists {
+		section = &changeStateSection{Type: c.Type, Anonymous: c.Procedure == ChangeAdd, Options: make(map[string]uci.List)}
+		s.sections[c.Section] = section
+		s.order = append(s.order, c.Section)
+	}
+
+	switch c.Procedure {
+	case ChangeSet:
+		if c.Option == "" {
+			section.Type = c.Type
+		} else {
+			section.Options[c.Option] = uci.List{c.Value}
+		}
+	case ChangeRemove:
+		if c.Option == "" {
+			delete(s.sections, c.Section)
+			s.order = slices.DeleteFunc(s.order, func(name string) bool { return name == c.Section })
+		} else {
+			delete(section.Options, c.Option)
+		}
+	case ChangeListAdd:
+		section.Options[c.Option] = append(section.Options[c.Option], c.Value)
+	case ChangeListDel:
+		section.Options[c.Option] = slices.DeleteFunc(section.Options[c.Option], func(v string) bool {
+			return v == c.Value
+		})
+	case ChangeRename:
+		if c.Option == "" {
+			delete(s.sections, c.Section)
+			s.sections[c.Value] = section
+			s.order[slices.Index(s.order, c.Section)] = c.Value
+		} else {
+			section.Options[c.Value] = section.Options[c.Option]
+			delete(section.Options, c.Option)
+		}
+	case ChangeOrder:
+		index, _ := strconv.Atoi(c.Value)
+		if order, err := moveNameTo(s.order, c.Section, index); err == nil {
+			s.order = order
+		}
+	}
+}
+
+// returns names with section moved to index like `uci reorder` does, an index past the end moves
+// it to the end
+func moveNameTo(names []string, section string, index int) ([]string, error) {
+	from := slices.Index(names, section)
+	if from < 0 {
+		return nil, fmt.Errorf("section %s not found", section)
+	}
+	names = slices.Delete(slices.Clone(names), from, from+1)
+	return slices.Insert(names, min(max(index, 0), len(names)), section), nil
+}
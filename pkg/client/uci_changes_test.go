@@ -0,0 +1,260 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+)
+
+func genericSection(t *testing.T, data string) uci.GenericSection {
+	t.Helper()
+	var section uci.GenericSection
+	if err := json.Unmarshal([]byte(data), &section); err != nil {
+		t.Fatal(err)
+	}
+	return section
+}
+
+func TestChangesInverse(t *testing.T) {
+	lan := network.InterfaceSection{
+		StaticSectionOptions:    uci.StaticSectionOptions{Type: network.Interface, Name: "lan"},
+		InterfaceSectionOptions: network.InterfaceSectionOptions{Device: uci.Some("br-lan"), MTU: uci.Some[uci.Int](1500)},
+	}
+	bridge := network.DeviceSection{
+		StaticSectionOptions: uci.StaticSectionOptions{Anonymous: true, Type: network.Device, Name: "cfg02a1b2"},
+		DeviceSectionOptions: network.DeviceSectionOptions{Name: uci.Some("br-lan"), Ports: uci.Some(uci.List{"lan1", "lan2"})},
+	}
+	wan := network.InterfaceSection{
+		StaticSectionOptions:    uci.StaticSectionOptions{Type: network.Interface, Name: "wan"},
+		InterfaceSectionOptions: network.InterfaceSectionOptions{Device: uci.Some("eth1")},
+	}
+	before := map[string]UCIGetResult{"network": {Sections: []uci.ConfigSection{lan, bridge, wan}}}
+	restoreLan := "network.lan=interface\n" +
+		"network.lan.device='br-lan'\n" +
+		"network.lan.mtu='1500'\n"
+	restorePorts := "-network.cfg02a1b2.ports\n" +
+		"network.cfg02a1b2.ports+='lan1'\n" +
+		"network.cfg02a1b2.ports+='lan2'\n"
+
+	tests := []struct {
+		name     string
+		changes  []Change
+		expected string
+	}{
+		{
+			name:     "set",
+			changes:  []Change{{Procedure: ChangeSet, Section: "lan", Option: "device", Value: "eth0"}},
+			expected: "-network.lan.device\nnetwork.lan.device='br-lan'\n",
+		},
+		{
+			name:     "remove section",
+			changes:  []Change{{Procedure: ChangeRemove, Section: "lan"}},
+			expected: restoreLan + "^network.lan=0\n",
+		},
+		{
+			name:    "remove anonymous section",
+			changes: []Change{{Procedure: ChangeRemove, Section: "cfg02a1b2"}},
+			expected: "+network.cfg02a1b2=device\n" +
+				"network.cfg02a1b2.name='br-lan'\n" +
+				"network.cfg02a1b2.ports+='lan1'\n" +
+				"network.cfg02a1b2.ports+='lan2'\n" +
+				"^network.cfg02a1b2=1\n",
+		},
+		{
+			name:     "list del",
+			changes:  []Change{{Procedure: ChangeListDel, Section: "cfg02a1b2", Option: "ports", Value: "lan1"}},
+			expected: restorePorts,
+		},
+		{
+			name:    "list del of a missing value",
+			changes: []Change{{Procedure: ChangeListDel, Section: "cfg02a1b2", Option: "ports", Value: "lan3"}},
+		},
+		{
+			name:     "list add of an existing value",
+			changes:  []Change{{Procedure: ChangeListAdd, Section: "cfg02a1b2", Option: "ports", Value: "lan1"}},
+			expected: restorePorts,
+		},
+		{
+			name:     "order",
+			changes:  []Change{{Procedure: ChangeOrder, Section: "wan", Value: "0"}},
+			expected: "^network.wan=2\n",
+		},
+		{
+			name: "order and remove",
+			changes: []Change{
+				{Procedure: ChangeOrder, Section: "wan", Value: "0"},
+				{Procedure: ChangeRemove, Section: "lan"},
+			},
+			expected: restoreLan + "^network.lan=1\n^network.wan=2\n",
+		},
+		{
+			name: "several changes",
+			changes: []Change{
+				{Procedure: ChangeSet, Section: "lan", Option: "device", Value: "eth0"},
+				{Procedure: ChangeAdd, Section: "cfg0a1234", Type: "route"},
+				{Procedure: ChangeListDel, Section: "cfg02a1b2", Option: "ports", Value: "lan2"},
+			},
+			expected: restorePorts +
+				"-network.cfg0a1234\n" +
+				"-network.lan.device\n" +
+				"network.lan.device='br-lan'\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes := UCIChangesResult{Changes: map[string][]Change{"network": test.changes}}
+			inverse, err := changes.Inverse(before)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := inverse.Diff(); actual != test.expected {
+				t.Error("\nexpected:\n", test.expected, "\nactual:\n", actual)
+			}
+		})
+	}
+
+	for _, c := range []Change{
+		{Procedure: ChangeOrder, Section: "guest", Value: "0"},
+		{Procedure: ChangeOrder, Section: "wan", Value: "first"},
+	} {
+		changes := UCIChangesResult{Changes: map[string][]Change{"network": {c}}}
+		if _, err := changes.Inverse(before); err == nil {
+			t.Errorf("expected an error for %s", c)
+		}
+	}
+}
+
+func TestChangesScript(t *testing.T) {
+	changes := UCIChangesResult{Changes: map[string][]Change{
+		"network": {
+			{Procedure: ChangeAdd, Section: "cfg0a1234", Type: "route"},
+			{Procedure: ChangeSet, Section: "cfg0a1234", Option: "target", Value: "10.0.0.0/8"},
+			{Procedure: ChangeListAdd, Section: "lan", Option: "dns", Value: "1.1.1.1"},
+			{Procedure: ChangeRemove, Section: "wan", Option: "mtu"},
+		},
+	}}
+
+	script, err := changes.Script()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := UCIScript{
+		{Signature: UCIAddOptions{Config: "network", Type: "route"}, Placeholder: "cfg0a1234"},
+		{Signature: UCISetOptions{Config: "network", Section: "cfg0a1234", Values: rawValues{"target": json.RawMessage(`"10.0.0.0/8"`)}}},
+		{Signature: UCIAddListOptions{Config: "network", Section: "lan", Option: "dns", Values: []string{"1.1.1.1"}}},
+		{Signature: UCIDeleteOptions{Config: "network", Section: "wan", Option: "mtu"}},
+	}
+	if !reflect.DeepEqual(script, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", script)
+	}
+
+	// the placeholder of the add is replaced by the name rpcd gave the section
+	renamed := script[1].withSections(map[string]string{"cfg0a1234": "cfg0b5678"})
+	if section := renamed.Signature.(UCISetOptions).Section; section != "cfg0b5678" {
+		t.Error("expected the renamed section, got", section)
+	}
+
+	// order changes move a single section, also one added earlier
+	changes.Changes["network"] = []Change{{Procedure: ChangeOrder, Section: "cfg0a1234", Value: "1"}}
+	if script, err = changes.Script(); err != nil {
+		t.Fatal(err)
+	}
+	moved := script[0].withSections(map[string]string{"cfg0a1234": "cfg0b5678"})
+	if expected := (UCIMoveOptions{Config: "network", Section: "cfg0b5678", Index: 1}); moved.Signature != expected {
+		t.Error("\nexpected: ", expected, "\nactual: ", moved.Signature)
+	}
+
+	changes.Changes["network"] = []Change{{Procedure: ChangeRename, Section: "lan", Value: "lan2"}}
+	if _, err = changes.Script(); err == nil {
+		t.Error("expected an error for a rename change")
+	}
+}
+
+func TestChangesDiff(t *testing.T) {
+	changes := UCIChangesResult{Changes: map[string][]Change{
+		"network": {{Procedure: ChangeSet, Section: "lan", Option: "proto", Value: "static"}},
+		"firewall": {
+			{Procedure: ChangeAdd, Section: "cfg0fad58", Type: "forwarding"},
+			{Procedure: ChangeRemove, Section: "cfg04ad58", Option: "name"},
+			{Procedure: ChangeListAdd, Section: "lan", Option: "network", Value: "guest"},
+		},
+	}}
+
+	expected := "+firewall.cfg0fad58=forwarding\n" +
+		"-firewall.cfg04ad58.name\n" +
+		"firewall.lan.network+='guest'\n" +
+		"network.lan.proto='static'\n"
+	if actual := changes.Diff(); actual != expected {
+		t.Error("\nexpected:\n", expected, "\nactual:\n", actual)
+	}
+}
+
+func TestMoveNameTo(t *testing.T) {
+	names := []string{"a", "b", "c"}
+
+	for index, expected := range map[int][]string{
+		-1: {"c", "a", "b"},
+		0:  {"c", "a", "b"},
+		1:  {"a", "c", "b"},
+		2:  {"a", "b", "c"},
+		5:  {"a", "b", "c"},
+	} {
+		if actual, err := moveNameTo(names, "c", index); err != nil || !slices.Equal(actual, expected) {
+			t.Error(index, "\nexpected: ", expected, "\nactual: ", actual, err)
+		}
+	}
+	if _, err := moveNameTo(names, "x", 0); err == nil {
+		t.Error("expected an error for a missing section")
+	}
+	if !slices.Equal(names, []string{"a", "b", "c"}) {
+		t.Error("names was modified:", names)
+	}
+}
+
+func TestMove(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string {
+		if call.Procedure == "get" {
+			return `[0,{"values":{` +
+				`"lan":{".anonymous":false,".type":"interface",".name":"lan",".index":0},` +
+				`"wan":{".anonymous":false,".type":"interface",".name":"wan",".index":1},` +
+				`"guest":{".anonymous":false,".type":"interface",".name":"guest",".index":2}}}]`
+		}
+		return `[0]`
+	})
+
+	if _, err := rpc.UCI().Move(context.Background(), UCIMoveOptions{Config: "network", Section: "guest", Index: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if actual := procedures(*calls); !slices.Equal(actual, []string{"uci.get", "uci.order"}) {
+		t.Fatal("unexpected calls:", actual)
+	}
+	if actual := string((*calls)[1].Signature); actual != `{"config":"network","sections":["lan","guest","wan"]}` {
+		t.Error("unexpected order:", actual)
+	}
+
+	if _, err := rpc.UCI().Move(context.Background(), UCIMoveOptions{Config: "network", Section: "dmz"}); err == nil {
+		t.Error("expected an error for a missing section")
+	}
+}
@@ -0,0 +1,269 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+type DiffChange string
+
+const (
+	DiffAdded   DiffChange = "added"
+	DiffRemoved DiffChange = "removed"
+	DiffChanged DiffChange = "changed"
+)
+
+// the differences between two UCIGetResults of the same config, see UCIGetResult.Diff
+type UCIDiff struct {
+	Sections []SectionDiff `json:"sections,omitempty"`
+	// the names of the sections in the newer result, only set if the sections both results have
+	// are in a different order
+	Order []string `json:"order,omitempty"`
+}
+
+type SectionDiff struct {
+	Change DiffChange `json:"change"`
+	// the name in the newer result, or in the older one if the section was removed
+	Name string `json:"name"`
+	// only set if an anonymous section has a different name in the older result
+	OldName string       `json:"oldName,omitempty"`
+	Type    string       `json:"type"`
+	Options []OptionDiff `json:"options,omitempty"`
+}
+
+type OptionDiff struct {
+	Change DiffChange `json:"change"`
+	Name   string     `json:"name"`
+	// always lists in JSON, even for options with a single value
+	Old []string `json:"old,omitempty"`
+	New []string `json:"new,omitempty"`
+}
+
+// returns what changed from u to other, e.g. before and after staging changes or router A and
+// router B. named sections are matched by name and type, anonymous ones by identical options,
+// then by name, by their "name" option and finally by type and position. removed sections come
+// first, then added and changed ones in the order of other
+func (u UCIGetResult) Diff(other UCIGetResult) (diff UCIDiff, err error) {
+	matches := matchSections(u.Sections, other.Sections, true)
+	matched := make(map[int]bool, len(matches))
+	for _, i := range matches {
+		matched[i] = true
+	}
+
+	for i, section := range u.Sections {
+		if matched[i] {
+			continue
+		}
+		options, err := optionDiffs(section, nil)
+		if err != nil {
+			return diff, err
+		}
+		diff.Sections = append(diff.Sections, SectionDiff{
+			Change:  DiffRemoved,
+			Name:    section.GetName(),
+			Type:    section.GetType(),
+			Options: options,
+		})
+	}
+
+	last := -1
+	for j, section := range other.Sections {
+		i := matches[j]
+		if i < 0 {
+			options, err := optionDiffs(nil, section)
+			if err != nil {
+				return diff, err
+			}
+			diff.Sections = append(diff.Sections, SectionDiff{
+				Change:  DiffAdded,
+				Name:    section.GetName(),
+				Type:    section.GetType(),
+				Options: options,
+			})
+			continue
+		}
+
+		if i < last && diff.Order == nil {
+			for _, s := range other.Sections {
+				diff.Order = append(diff.Order, s.GetName())
+			}
+		}
+		last = max(last, i)
+
+		options, err := optionDiffs(u.Sections[i], section)
+		if err != nil {
+			return diff, err
+		}
+		if len(options) == 0 {
+			continue
+		}
+		s := SectionDiff{Change: DiffChanged, Name: section.GetName(), Type: section.GetType(), Options: options}
+		if name := u.Sections[i].GetName(); name != section.GetName() {
+			s.OldName = name
+		}
+		diff.Sections = append(diff.Sections, s)
+	}
+
+	return diff, nil
+}
+
+// reports whether both results have the same sections and options
+func (d UCIDiff) Empty() bool {
+	return len(d.Sections) == 0 && len(d.Order) == 0
+}
+
+// renders the diff for review, e.g.
+//
+//	~ lan (interface)
+//	    ~ ipaddr='192.168.1.1' -> '192.168.2.1'
+//	- cfg0c92bd (rule)
+//	    - name='Allow-Ping'
+//	+ lan6 (interface)
+//	    + proto='dhcpv6'
+//	^ loopback lan lan6 wan
+func (d UCIDiff) String() string {
+	var b strings.Builder
+
+	for _, s := range d.Sections {
+		fmt.Fprintf(&b, "%s %s (%s", diffPrefix(s.Change), s.Name, s.Type)
+		if s.OldName != "" {
+			fmt.Fprintf(&b, ", was %s", s.OldName)
+		}
+		b.WriteString(")\n")
+		for _, o := range s.Options {
+			switch o.Change {
+			case DiffAdded:
+				fmt.Fprintf(&b, "    + %s='%s'\n", o.Name, strings.Join(o.New, " "))
+			case DiffRemoved:
+				fmt.Fprintf(&b, "    - %s='%s'\n", o.Name, strings.Join(o.Old, " "))
+			case DiffChanged:
+				fmt.Fprintf(&b, "    ~ %s='%s' -> '%s'\n", o.Name, strings.Join(o.Old, " "), strings.Join(o.New, " "))
+			}
+		}
+	}
+	if len(d.Order) > 0 {
+		fmt.Fprintf(&b, "^ %s\n", strings.Join(d.Order, " "))
+	}
+
+	return b.String()
+}
+
+func diffPrefix(c DiffChange) string {
+	switch c {
+	case DiffAdded:
+		return "+"
+	case DiffRemoved:
+		return "-"
+	}
+	return "~"
+}
+
+// returns the differing options of two sections sorted by name, either of them can be nil
+func optionDiffs(old, new uci.ConfigSection) (diffs []OptionDiff, err error) {
+	oldValues, newValues := map[string]uci.List{}, map[string]uci.List{}
+	if old != nil {
+		if oldValues, err = uci.OptionValues(old); err != nil {
+			return nil, err
+		}
+	}
+	if new != nil {
+		if newValues, err = uci.OptionValues(new); err != nil {
+			return nil, err
+		}
+	}
+
+	names := slices.Collect(maps.Keys(oldValues))
+	for name := range newValues {
+		if _, ok := oldValues[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		o, inOld := oldValues[name]
+		n, inNew := newValues[name]
+		switch {
+		case !inOld:
+			diffs = append(diffs, OptionDiff{Change: DiffAdded, Name: name, New: n})
+		case !inNew:
+			diffs = append(diffs, OptionDiff{Change: DiffRemoved, Name: name, Old: o})
+		case !slices.Equal(o, n):
+			diffs = append(diffs, OptionDiff{Change: DiffChanged, Name: name, Old: o, New: n})
+		}
+	}
+
+	return diffs, nil
+}
+
+// returns the index in current of the section matching each of sections, or -1 if there is none.
+// sections only match ones of the same type which are just as anonymous. named sections are matched
+// by name, anonymous ones by identical options if byOptions is set, then by .name, by their "name"
+// option and finally by type in order
+func matchSections(current, sections []uci.ConfigSection, byOptions bool) []int {
+	matches := make([]int, len(sections))
+	used := make(map[int]bool)
+	// matches each section for which anonymous is set with the first unused section of current
+	// for which match returns true
+	claim := func(anonymous bool, match func(section, c uci.ConfigSection) bool) {
+		for j, section := range sections {
+			if matches[j] >= 0 || isAnonymous(section) != anonymous {
+				continue
+			}
+			for i, c := range current {
+				if !used[i] && c.GetType() == section.GetType() && isAnonymous(c) == anonymous && match(section, c) {
+					matches[j] = i
+					used[i] = true
+					break
+				}
+			}
+		}
+	}
+	values := func(section uci.ConfigSection) map[string]uci.List {
+		v, _ := uci.OptionValues(section)
+		return v
+	}
+	for j := range matches {
+		matches[j] = -1
+	}
+
+	claim(false, func(section, c uci.ConfigSection) bool {
+		return c.GetName() == section.GetName()
+	})
+	if byOptions {
+		claim(true, func(section, c uci.ConfigSection) bool {
+			return maps.EqualFunc(values(c), values(section), slices.Equal)
+		})
+	}
+	claim(true, func(section, c uci.ConfigSection) bool {
+		return section.GetName() != "" && c.GetName() == section.GetName()
+	})
+	claim(true, func(section, c uci.ConfigSection) bool {
+		name := values(section)["name"]
+		return len(name) > 0 && slices.Equal(values(c)["name"], name)
+	})
+	claim(true, func(uci.ConfigSection, uci.ConfigSection) bool { return true })
+
+	return matches
+}
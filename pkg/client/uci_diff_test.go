@@ -0,0 +1,118 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestDiff(t *testing.T) {
+	lan := genericSection(t, `{".anonymous":false,".type":"interface",".name":"lan","proto":"static","ipaddr":"192.168.1.1"}`)
+	wan := genericSection(t, `{".anonymous":false,".type":"interface",".name":"wan","proto":"dhcp"}`)
+	ping := genericSection(t, `{".anonymous":true,".type":"rule",".name":"cfg02ba3c","name":"Allow-Ping","target":"ACCEPT"}`)
+	ssh := genericSection(t, `{".anonymous":true,".type":"rule",".name":"cfg03ba3c","name":"Allow-SSH","target":"ACCEPT"}`)
+	before := UCIGetResult{Sections: []uci.ConfigSection{lan, wan, ping, ssh}}
+
+	changedLan := genericSection(t, `{".anonymous":false,".type":"interface",".name":"lan","proto":"static","ipaddr":"192.168.2.1"}`)
+	lan6 := genericSection(t, `{".anonymous":false,".type":"interface",".name":"lan6","proto":"dhcpv6"}`)
+	// the same rules in the other order, so they were renamed
+	movedSSH := genericSection(t, `{".anonymous":true,".type":"rule",".name":"cfg02ba3c","name":"Allow-SSH","target":"ACCEPT"}`)
+	movedPing := genericSection(t, `{".anonymous":true,".type":"rule",".name":"cfg03ba3c","name":"Allow-Ping","target":"DROP"}`)
+	// the wan interface was replaced by a device of the same name
+	wanDevice := genericSection(t, `{".anonymous":false,".type":"device",".name":"wan","name":"eth1"}`)
+
+	tests := []struct {
+		name     string
+		after    []uci.ConfigSection
+		expected string
+	}{
+		{
+			name:  "unchanged",
+			after: []uci.ConfigSection{lan, wan, ping, ssh},
+		},
+		{
+			name:  "options",
+			after: []uci.ConfigSection{changedLan, wan, ping, ssh, lan6},
+			expected: "~ lan (interface)\n" +
+				"    ~ ipaddr='192.168.1.1' -> '192.168.2.1'\n" +
+				"+ lan6 (interface)\n" +
+				"    + proto='dhcpv6'\n",
+		},
+		{
+			name:  "removed and type change",
+			after: []uci.ConfigSection{lan, wanDevice, ping},
+			expected: "- wan (interface)\n" +
+				"    - proto='dhcp'\n" +
+				"- cfg03ba3c (rule)\n" +
+				"    - name='Allow-SSH'\n" +
+				"    - target='ACCEPT'\n" +
+				"+ wan (device)\n" +
+				"    + name='eth1'\n",
+		},
+		{
+			name:  "anonymous by content and name option",
+			after: []uci.ConfigSection{lan, wan, movedSSH, movedPing},
+			expected: "~ cfg03ba3c (rule, was cfg02ba3c)\n" +
+				"    ~ target='ACCEPT' -> 'DROP'\n" +
+				"^ lan wan cfg02ba3c cfg03ba3c\n",
+		},
+		{
+			name:     "order",
+			after:    []uci.ConfigSection{wan, lan, ping, ssh},
+			expected: "^ wan lan cfg02ba3c cfg03ba3c\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diff, err := before.Diff(UCIGetResult{Sections: test.after})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := diff.String(); actual != test.expected {
+				t.Errorf("\nexpected:\n%s\nactual:\n%s", test.expected, actual)
+			}
+			if diff.Empty() != (test.expected == "") {
+				t.Error("unexpected Empty:", diff.Empty())
+			}
+		})
+	}
+}
+
+func TestMatchSections(t *testing.T) {
+	current := []uci.ConfigSection{
+		genericSection(t, `{".anonymous":true,".type":"zone",".name":"cfg01dc81","name":"lan","input":"ACCEPT"}`),
+		genericSection(t, `{".anonymous":true,".type":"zone",".name":"cfg02dc81","name":"wan","input":"REJECT"}`),
+		genericSection(t, `{".anonymous":false,".type":"zone",".name":"guest","name":"guest"}`),
+	}
+	sections := []uci.ConfigSection{
+		genericSection(t, `{".anonymous":true,".type":"zone",".name":"cfg01dc81","name":"wan","input":"REJECT"}`),
+		genericSection(t, `{".anonymous":true,".type":"zone",".name":"cfg02dc81","name":"lan","input":"REJECT"}`),
+		genericSection(t, `{".anonymous":true,".type":"zone",".name":"guest","name":"guest"}`),
+	}
+
+	// identical options go before names, the anonymous guest zone does not match the named one
+	if matches := matchSections(current, sections, true); !slices.Equal(matches, []int{1, 0, -1}) {
+		t.Error("unexpected matches by options:", matches)
+	}
+	if matches := matchSections(current, sections, false); !slices.Equal(matches, []int{0, 1, -1}) {
+		t.Error("unexpected matches by name:", matches)
+	}
+}
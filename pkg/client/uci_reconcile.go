@@ -0,0 +1,500 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+// the desired state of a config, see Plan
+type DesiredConfig struct {
+	Config string
+	// the sections in the order they should have on the router. sections with a .name are matched
+	// to the router's by name, the remaining anonymous ones by their "name" option if they have one,
+	// or else by type and position. unset options are left alone, options marked as deleted are removed
+	Sections []uci.ConfigSection
+	// also delete every section and option of the config which is not in Sections
+	Prune bool
+}
+
+// parses desired configs from YAML, one config per document:
+//
+//	config: firewall
+//	prune: true
+//	sections:
+//	  - .type: defaults
+//	    input: REJECT
+//	  - .type: zone
+//	    .name: lan
+//	    name: lan
+//	    network: [lan]
+//	    input: ACCEPT
+//
+// sections are decoded with the models registered for the config, or as generic sections if a model
+// does not know all of their options (see uci.UnmarshalSectionLossless). lists can be written as
+// sequences and booleans as true/false. null marks an option as deleted
+func ParseDesiredConfigs(data []byte) (configs []DesiredConfig, err error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc struct {
+			Config   string      `yaml:"config"`
+			Prune    bool        `yaml:"prune"`
+			Sections []yaml.Node `yaml:"sections"`
+		}
+		if err = dec.Decode(&doc); errors.Is(err, io.EOF) {
+			return configs, nil
+		} else if err != nil {
+			return nil, err
+		} else if doc.Config == "" {
+			return nil, errors.New("desired config without a config name")
+		}
+
+		desired := DesiredConfig{Config: doc.Config, Prune: doc.Prune}
+		for i, node := range doc.Sections {
+			section, err := yamlSection(doc.Config, &node)
+			if err != nil {
+				return nil, fmt.Errorf("%s: section %d: %w", doc.Config, i, err)
+			}
+			desired.Sections = append(desired.Sections, section)
+		}
+		configs = append(configs, desired)
+	}
+}
+
+// uci stores every value as a string, so YAML scalars are passed on as written instead of being
+// converted to YAML's own types first
+func yamlSection(config string, node *yaml.Node) (uci.ConfigSection, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: not a mapping", node.Line)
+	}
+
+	raw := make(map[string]any, len(node.Content)/2+1)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		switch {
+		case value.Kind == yaml.SequenceNode:
+			list := make([]string, 0, len(value.Content))
+			for _, item := range value.Content {
+				s, err := yamlScalar(item)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, s)
+			}
+			raw[key] = list
+		case value.Tag == "!!null":
+			raw[key] = nil
+		default:
+			s, err := yamlScalar(value)
+			if err != nil {
+				return nil, err
+			}
+			raw[key] = s
+		}
+	}
+	if _, ok := raw[".type"]; !ok {
+		return nil, fmt.Errorf("line %d: missing .type", node.Line)
+	}
+	name, _ := raw[".name"].(string)
+	raw[".anonymous"] = name == ""
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	return uci.UnmarshalSectionLossless(config, data)
+}
+
+func yamlScalar(node *yaml.Node) (string, error) {
+	if node.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("line %d: not a scalar", node.Line)
+	}
+	if node.Tag == "!!bool" {
+		var b bool
+		if err := node.Decode(&b); err != nil {
+			return "", err
+		}
+		if b {
+			return "1", nil
+		}
+		return "0", nil
+	}
+	return node.Value, nil
+}
+
+// the calls which converge the router to the desired state, see Plan
+type UCIPlan struct {
+	Script UCIScript
+}
+
+// reports whether the router already is in the desired state
+func (p UCIPlan) Empty() bool {
+	return len(p.Script) == 0
+}
+
+// renders the plan for review, one call per line:
+//
+//	~ network.lan ipaddr='192.168.2.1'
+//	- firewall.cfg0c92bd
+//	- firewall.lan masq
+//	~ firewall.lan input='ACCEPT' network='lan guest'
+//	+ firewall.@new0=rule
+//	^ firewall lan wan @new0
+//
+// sections which do not exist yet are shown with the placeholder that stands in for their name
+func (p UCIPlan) String() string {
+	var b strings.Builder
+
+	for _, call := range p.Script {
+		switch opts := call.Signature.(type) {
+		case UCIAddOptions:
+			name := opts.Name
+			if call.Placeholder != "" {
+				name = call.Placeholder
+			}
+			fmt.Fprintf(&b, "+ %s.%s=%s%s\n", opts.Config, name, opts.Type, formatValues(opts.Values))
+		case UCISetOptions:
+			fmt.Fprintf(&b, "~ %s.%s%s\n", opts.Config, opts.Section, formatValues(opts.Values))
+		case UCIDeleteOptions:
+			fmt.Fprintf(&b, "- %s.%s", opts.Config, opts.Section)
+			if len(opts.Options) > 0 {
+				b.WriteString(" " + strings.Join(opts.Options, " "))
+			}
+			b.WriteString("\n")
+		case UCIOrderOptions:
+			fmt.Fprintf(&b, "^ %s %s\n", opts.Config, strings.Join(opts.Sections, " "))
+		}
+	}
+
+	return b.String()
+}
+
+func formatValues(o uci.ConfigSectionOptions) string {
+	values, ok := o.(rawValues)
+	if !ok {
+		return ""
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		var list uci.List
+		if err := json.Unmarshal(values[name], &list); err != nil {
+			continue
+		}
+		fmt.Fprintf(&b, " %s='%s'", name, strings.Join(list, " "))
+	}
+	return b.String()
+}
+
+// compares the desired configs with the router and returns the add, set, delete and order calls
+// which converge it, nothing is staged
+func Plan(ctx context.Context, rpc *UbusRPC, desired ...DesiredConfig) (plan UCIPlan, err error) {
+	added := 0
+	for _, d := range desired {
+		response, err := rpc.UCI().Get(ctx, UCIGetOptions{Config: d.Config})
+		if err != nil {
+			return plan, err
+		}
+		// the current state has to keep options the models do not know, otherwise they could
+		// neither be compared nor pruned
+		current, err := losslessResult(d.Config, response)
+		if err != nil {
+			return plan, err
+		}
+
+		script, err := d.plan(current, &added)
+		if err != nil {
+			return plan, fmt.Errorf("%s: %w", d.Config, err)
+		}
+		plan.Script = append(plan.Script, script...)
+	}
+
+	return plan, nil
+}
+
+// added numbers the placeholders of new anonymous sections, it is shared between configs since
+// UCIScript.Run resolves placeholders regardless of config
+func (d DesiredConfig) plan(current UCIGetResult, added *int) (script UCIScript, err error) {
+	for _, section := range d.Sections {
+		if err = uci.Validate(optionsOf(section)); err != nil {
+			return nil, fmt.Errorf("section %s: %w", describe(section), err)
+		}
+	}
+
+	matches := d.match(current)
+
+	// deletions first, so sections whose type changed can be added again under the same name
+	for _, section := range current.Sections {
+		match := slices.IndexFunc(matches, func(m uci.ConfigSection) bool {
+			return m != nil && m.GetName() == section.GetName()
+		})
+		sameName := slices.ContainsFunc(d.Sections, func(s uci.ConfigSection) bool {
+			return !isAnonymous(s) && s.GetName() == section.GetName()
+		})
+		if match < 0 && (d.Prune || sameName) {
+			script = append(script, UCICall{Signature: UCIDeleteOptions{Config: d.Config, Section: section.GetName()}})
+		}
+	}
+
+	var names []string
+	for i, section := range d.Sections {
+		desiredValues, err := uci.OptionValues(section)
+		if err != nil {
+			return nil, err
+		}
+
+		if matches[i] == nil {
+			call := UCICall{Signature: UCIAddOptions{Config: d.Config, Type: section.GetType(), Name: section.GetName()}}
+			name := section.GetName()
+			if isAnonymous(section) {
+				// uci names anonymous sections itself
+				opts := call.Signature.(UCIAddOptions)
+				opts.Name = ""
+				call.Signature = opts
+				name = fmt.Sprintf("@new%d", *added)
+				call.Placeholder = name
+				*added++
+			}
+			if len(desiredValues) > 0 {
+				opts := call.Signature.(UCIAddOptions)
+				if opts.Values, err = toRawValues(desiredValues); err != nil {
+					return nil, err
+				}
+				call.Signature = opts
+			}
+			script = append(script, call)
+			names = append(names, name)
+			continue
+		}
+
+		name := matches[i].GetName()
+		currentValues, err := uci.OptionValues(matches[i])
+		if err != nil {
+			return nil, err
+		}
+
+		var deleted []string
+		for _, option := range uci.MarkedDeleted(optionsOf(section)) {
+			if _, ok := currentValues[option]; ok {
+				deleted = append(deleted, option)
+			}
+		}
+		changed := make(map[string]uci.List)
+		for option := range currentValues {
+			if _, ok := desiredValues[option]; !ok && d.Prune && !slices.Contains(deleted, option) {
+				deleted = append(deleted, option)
+			}
+		}
+		for option, value := range desiredValues {
+			if !slices.Equal(currentValues[option], value) {
+				changed[option] = value
+			}
+		}
+
+		if len(deleted) > 0 {
+			sort.Strings(deleted)
+			script = append(script, UCICall{Signature: UCIDeleteOptions{Config: d.Config, Section: name, Options: deleted}})
+		}
+		if len(changed) > 0 {
+			values, err := toRawValues(changed)
+			if err != nil {
+				return nil, err
+			}
+			script = append(script, UCICall{Signature: UCISetOptions{Config: d.Config, Section: name, Values: values}})
+		}
+		names = append(names, name)
+	}
+
+	if !slices.Equal(d.projectedOrder(current, matches, names), names) {
+		script = append(script, UCICall{Signature: UCIOrderOptions{Config: d.Config, Sections: names}})
+	}
+
+	return script, nil
+}
+
+// returns the current section matching each of d.Sections, or nil for the ones which have to be
+// added, see matchSections. a section named like a current one of another type replaces it
+func (d DesiredConfig) match(current UCIGetResult) []uci.ConfigSection {
+	matches := make([]uci.ConfigSection, len(d.Sections))
+	for j, i := range matchSections(current.Sections, d.Sections, false) {
+		if i >= 0 {
+			matches[j] = current.Sections[i]
+		}
+	}
+	return matches
+}
+
+// returns the order the desired sections would have without an order call: matched sections keep
+// their current position and added ones are appended
+func (d DesiredConfig) projectedOrder(current UCIGetResult, matches []uci.ConfigSection, names []string) []string {
+	order := make([]string, 0, len(names))
+	for _, section := range current.Sections {
+		if slices.ContainsFunc(matches, func(m uci.ConfigSection) bool {
+			return m != nil && m.GetName() == section.GetName()
+		}) {
+			order = append(order, section.GetName())
+		}
+	}
+	for i, match := range matches {
+		if match == nil {
+			order = append(order, names[i])
+		}
+	}
+	return order
+}
+
+// sections without a name are anonymous too, e.g. ones built in Go which only set the type
+func isAnonymous(section uci.ConfigSection) bool {
+	return section.IsAnonymous() || section.GetName() == ""
+}
+
+func optionsOf(section uci.ConfigSection) uci.ConfigSectionOptions {
+	switch s := section.(type) {
+	case uci.GenericSection:
+		return s.Options
+	case uci.ConfigSectionOptions:
+		return s
+	}
+	return nil
+}
+
+func describe(section uci.ConfigSection) string {
+	if section.GetName() != "" {
+		return section.GetName()
+	}
+	return "of type " + section.GetType()
+}
+
+func toRawValues(values map[string]uci.List) (rawValues, error) {
+	raw := make(rawValues, len(values))
+	for name, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		raw[name] = data
+	}
+	return raw, nil
+}
+
+// how UCIPlan.Apply applies the staged plan
+type PlanApplyOptions struct {
+	// seconds the router waits for the changes to be confirmed before rolling them back, rpcd
+	// uses 30 if this is 0
+	Timeout int
+	// run after the changes are applied, e.g. to make sure the router is still reachable. the
+	// changes are rolled back if it returns an error, otherwise they are confirmed
+	Check func(ctx context.Context) error
+}
+
+// returns a PlanApplyOptions.Check which only passes if rpc can still reach the router, e.g. after
+// the changes touched its address or firewall
+func CheckReachable(rpc *UbusRPC) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := rpc.UCI().Configs(ctx, UCIConfigsOptions{})
+		return err
+	}
+}
+
+// stages the plan and applies it with rollback. no config may have pending changes, not only the
+// ones the plan touches, since `uci apply` applies all of them. if staging fails the configs of the
+// plan are reverted again
+func (p UCIPlan) Apply(ctx context.Context, rpc *UbusRPC, opts PlanApplyOptions) error {
+	if p.Empty() {
+		return nil
+	}
+
+	changesOpts := UCIChangesOptions{}
+	response, err := rpc.UCI().Changes(ctx, changesOpts)
+	if err != nil {
+		return err
+	}
+	changes, err := changesOpts.GetResult(response)
+	if err != nil {
+		return err
+	}
+	for _, config := range slices.Sorted(maps.Keys(changes.Changes)) {
+		if len(changes.Changes[config]) > 0 {
+			return fmt.Errorf("config %s has pending changes, apply or revert them first", config)
+		}
+	}
+
+	configs := p.configs()
+
+	revert := func(err error) error {
+		for _, config := range configs {
+			if _, revertErr := rpc.UCI().Revert(ctx, UCIRevertOptions{Config: config}); revertErr != nil {
+				return errors.Join(err, revertErr)
+			}
+		}
+		return err
+	}
+	if err := p.Script.Run(ctx, rpc); err != nil {
+		return revert(err)
+	}
+	if _, err := rpc.UCI().Apply(ctx, UCIApplyOptions{Rollback: true, Timeout: opts.Timeout}); err != nil {
+		return revert(err)
+	}
+
+	if opts.Check != nil {
+		if err := opts.Check(ctx); err != nil {
+			_, rollbackErr := rpc.UCI().Rollback(ctx, UCIRollbackOptions{})
+			return errors.Join(fmt.Errorf("rolled back: %w", err), rollbackErr)
+		}
+	}
+	_, err = rpc.UCI().Confirm(ctx, UCIConfirmOptions{})
+	return err
+}
+
+func (p UCIPlan) configs() (configs []string) {
+	for _, call := range p.Script {
+		var config string
+		switch opts := call.Signature.(type) {
+		case UCIAddOptions:
+			config = opts.Config
+		case UCISetOptions:
+			config = opts.Config
+		case UCIDeleteOptions:
+			config = opts.Config
+		case UCIOrderOptions:
+			config = opts.Config
+		}
+		if !slices.Contains(configs, config) {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/uciformat"
+)
+
+const currentFirewall = `[0,{"values":{` +
+	`"cfg01e63d":{".anonymous":true,".type":"defaults",".name":"cfg01e63d",".index":0,"input":"REJECT"},` +
+	`"lan":{".anonymous":false,".type":"zone",".name":"lan",".index":1,"name":"lan","input":"ACCEPT","masq":"1"},` +
+	`"cfg03dc81":{".anonymous":true,".type":"zone",".name":"cfg03dc81",".index":2,"name":"wan","input":"REJECT"},` +
+	`"cfg04ad58":{".anonymous":true,".type":"forwarding",".name":"cfg04ad58",".index":3,"src":"lan","dest":"wan"}}}]`
+
+func currentResult(t *testing.T) UCIGetResult {
+	t.Helper()
+	var response Response
+	if err := json.Unmarshal([]byte(currentFirewall), &response); err != nil {
+		t.Fatal(err)
+	}
+	current, err := losslessResult(firewall.Config, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return current
+}
+
+func TestParseDesiredConfigs(t *testing.T) {
+	configs, err := ParseDesiredConfigs([]byte(`config: firewall
+prune: true
+sections:
+  - .type: zone
+    .name: lan
+    network: [lan, guest]
+    masq: true
+    forward: null
+  - .type: zone
+    name: wan
+    bogus_opt: kept
+---
+config: network
+sections: []
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(configs) != 2 || configs[0].Config != firewall.Config || !configs[0].Prune || configs[1].Prune {
+		t.Fatalf("unexpected configs: %+v", configs)
+	}
+
+	lan, ok := configs[0].Sections[0].(firewall.ZoneSection)
+	if !ok {
+		t.Fatalf("expected a ZoneSection, got %T", configs[0].Sections[0])
+	}
+	if lan.IsAnonymous() || !slices.Equal(lan.Network.Value(), uci.List{"lan", "guest"}) || !bool(lan.Masq.Value()) || !lan.Forward.IsDeleted() {
+		t.Errorf("unexpected lan section: %+v", lan)
+	}
+	// options the model does not know are not dropped
+	wan, ok := configs[0].Sections[1].(uci.GenericSection)
+	if !ok {
+		t.Fatalf("expected a GenericSection, got %T", configs[0].Sections[1])
+	}
+	if !wan.IsAnonymous() || !slices.Equal(wan.Options["bogus_opt"], uci.List{"kept"}) {
+		t.Errorf("unexpected wan section: %+v", wan)
+	}
+
+	for _, data := range []string{
+		"sections: []\n",
+		"config: firewall\nsections:\n  - .name: lan\n",
+		"config: firewall\nsections:\n  - [zone]\n",
+	} {
+		if _, err = ParseDesiredConfigs([]byte(data)); err == nil {
+			t.Errorf("expected an error for %q", data)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		desired  string
+		expected string
+	}{
+		{
+			name: "unchanged",
+			desired: `config: firewall
+prune: true
+sections:
+  - {.type: defaults, input: REJECT}
+  - {.type: zone, .name: lan, name: lan, input: ACCEPT, masq: true}
+  - {.type: zone, name: wan, input: REJECT}
+  - {.type: forwarding, src: lan, dest: wan}
+`,
+		},
+		{
+			name: "match by name",
+			desired: `config: firewall
+sections:
+  - {.type: zone, .name: lan, input: REJECT, masq: null}
+`,
+			expected: "- firewall.lan masq\n" +
+				"~ firewall.lan input='REJECT'\n",
+		},
+		{
+			name: "match by name option",
+			desired: `config: firewall
+sections:
+  - {.type: zone, name: wan, input: ACCEPT}
+`,
+			expected: "~ firewall.cfg03dc81 input='ACCEPT'\n",
+		},
+		{
+			name: "match by type",
+			desired: `config: firewall
+sections:
+  - {.type: defaults, input: ACCEPT}
+  - {.type: forwarding, src: lan, dest: wan}
+  - {.type: forwarding, src: wan, dest: lan}
+`,
+			expected: "~ firewall.cfg01e63d input='ACCEPT'\n" +
+				"+ firewall.@new0=forwarding dest='lan' src='wan'\n",
+		},
+		{
+			name: "anonymous sections only match anonymous ones",
+			desired: `config: firewall
+sections:
+  - {.type: zone, .name: cfg03dc81, name: wan, input: REJECT}
+`,
+			expected: "- firewall.cfg03dc81\n" +
+				"+ firewall.cfg03dc81=zone input='REJECT' name='wan'\n",
+		},
+		{
+			name: "type change",
+			desired: `config: firewall
+sections:
+  - {.type: rule, .name: lan, name: lan}
+`,
+			expected: "- firewall.lan\n" +
+				"+ firewall.lan=rule name='lan'\n",
+		},
+		{
+			name: "prune",
+			desired: `config: firewall
+prune: true
+sections:
+  - {.type: defaults, input: REJECT}
+  - {.type: zone, .name: lan, name: lan}
+`,
+			expected: "- firewall.cfg03dc81\n" +
+				"- firewall.cfg04ad58\n" +
+				"- firewall.lan input masq\n",
+		},
+		{
+			name: "order",
+			desired: `config: firewall
+sections:
+  - {.type: zone, .name: lan}
+  - {.type: defaults}
+  - {.type: rule, name: new}
+`,
+			expected: "+ firewall.@new0=rule name='new'\n" +
+				"^ firewall lan cfg01e63d @new0\n",
+		},
+	}
+
+	current := currentResult(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			desired, err := ParseDesiredConfigs([]byte(test.desired))
+			if err != nil {
+				t.Fatal(err)
+			}
+			added := 0
+			script, err := desired[0].plan(current, &added)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := (UCIPlan{Script: script}).String(); actual != test.expected {
+				t.Errorf("\nexpected:\n%s\nactual:\n%s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	desired, err := ParseDesiredConfigs([]byte(`config: firewall
+sections:
+  - {.type: forwarding}
+  - {.type: zone, name: wan}
+  - {.type: zone}
+  - {.type: zone, .name: lan}
+  - {.type: zone}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	current := currentResult(t)
+	matches := desired[0].match(current)
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		if match != nil {
+			names[i] = match.GetName()
+		}
+	}
+	// the named lan zone is not matched by type, so only one anonymous zone is left for the
+	// zone without a name option after wan claimed it by name
+	expected := []string{"cfg04ad58", "cfg03dc81", "", "lan", ""}
+	if !slices.Equal(names, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", names)
+	}
+
+	order := desired[0].projectedOrder(current, matches, []string{"cfg04ad58", "cfg03dc81", "@new0", "lan", "@new1"})
+	expected = []string{"lan", "cfg03dc81", "cfg04ad58", "@new0", "@new1"}
+	if !slices.Equal(order, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", order)
+	}
+}
+
+func TestPlanFile(t *testing.T) {
+	const file = `config interface 'loopback'
+	option device 'lo'
+	option proto 'static'
+
+config globals 'globals'
+	option ula_prefix 'fd12:3456:789a::/48'
+
+config device
+	option name 'br-lan'
+	option type 'bridge'
+	list ports 'lan1'
+	list ports 'lan2'
+
+config interface 'lan'
+	option device 'br-lan'
+	option proto 'static'
+`
+	// what rpcd returns for the file, with the names libuci gave the anonymous sections
+	var response Response
+	if err := json.Unmarshal([]byte(`[0,{"values":{`+
+		`"loopback":{".anonymous":false,".type":"interface",".name":"loopback",".index":0,"device":"lo","proto":"static"},`+
+		`"globals":{".anonymous":false,".type":"globals",".name":"globals",".index":1,"ula_prefix":"fd12:3456:789a::/48"},`+
+		`"cfg030f15":{".anonymous":true,".type":"device",".name":"cfg030f15",".index":2,"name":"br-lan","type":"bridge","ports":["lan1","lan2"]},`+
+		`"lan":{".anonymous":false,".type":"interface",".name":"lan",".index":3,"device":"br-lan","proto":"static"}}}]`), &response); err != nil {
+		t.Fatal(err)
+	}
+	current, err := losslessResult(network.Config, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		file     string
+		expected string
+	}{
+		{file: file},
+		{
+			file:     strings.Replace(file, "list ports 'lan2'", "list ports 'lan3'", 1),
+			expected: "~ network.cfg030f15 ports='lan1 lan3'\n",
+		},
+	} {
+		f, err := uciformat.Parse(strings.NewReader(test.file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sections, err := f.ConfigSections(network.Config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.expected == "" {
+			for i, section := range sections {
+				if section.GetName() != current.Sections[i].GetName() {
+					t.Errorf("expected the name %s, got %s", current.Sections[i].GetName(), section.GetName())
+				}
+			}
+		}
+
+		// like gur uci import
+		d := DesiredConfig{Config: network.Config, Sections: sections, Prune: true}
+		added := 0
+		script, err := d.plan(current, &added)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if actual := (UCIPlan{Script: script}).String(); actual != test.expected {
+			t.Errorf("\nexpected:\n%s\nactual:\n%s", test.expected, actual)
+		}
+	}
+}
+
+func TestPlanApply(t *testing.T) {
+	plan := UCIPlan{Script: UCIScript{
+		{Signature: UCISetOptions{Config: network.Config, Section: "lan", Values: network.InterfaceSectionOptions{MTU: uci.Some[uci.Int](1400)}}},
+	}}
+
+	tests := []struct {
+		name     string
+		changes  string
+		check    func(context.Context) error
+		valid    bool
+		expected []string
+	}{
+		{
+			name:     "confirmed",
+			changes:  `[0,{"changes":{}}]`,
+			valid:    true,
+			expected: []string{"uci.changes", "uci.set", "uci.apply", "uci.confirm"},
+		},
+		{
+			name:     "pending changes in another config",
+			changes:  `[0,{"changes":{"dhcp":[["set","lan","leasetime","12h"]]}}]`,
+			expected: []string{"uci.changes"},
+		},
+		{
+			name:     "pending changes in the config of the plan",
+			changes:  `[0,{"changes":{"network":[["set","wan","proto","dhcp"]]}}]`,
+			expected: []string{"uci.changes"},
+		},
+		{
+			name:     "failed check",
+			changes:  `[0,{"changes":{}}]`,
+			check:    func(context.Context) error { return errors.New("unreachable") },
+			expected: []string{"uci.changes", "uci.set", "uci.apply", "uci.rollback"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rpc, calls := newFakeRPC(t, func(call fakeCall) string {
+				if call.Procedure == "changes" {
+					return test.changes
+				}
+				return `[0]`
+			})
+
+			err := plan.Apply(context.Background(), rpc, PlanApplyOptions{Check: test.check})
+			if (err == nil) != test.valid {
+				t.Errorf("expected valid: %v, got error: %v", test.valid, err)
+			}
+			if actual := procedures(*calls); !slices.Equal(actual, test.expected) {
+				t.Error("\nexpected: ", test.expected, "\nactual: ", actual)
+			}
+			// every config is checked, not only the ones of the plan
+			if actual := string((*calls)[0].Signature); actual != `{}` {
+				t.Error("unexpected changes signature:", actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+// returns all sections of config which decode into S, e.g.
+//
+//	zones, err := client.GetSections[firewall.ZoneSection](ctx, rpc, firewall.Config)
+//
+// S has to be registered with uci for config. the `uci get` is filtered by S's section type unless
+// it is registered by pattern, in which case the whole config is fetched and filtered here. no
+// matching sections is an empty slice, a config which does not exist is an error
+func GetSections[S uci.ConfigSection](ctx context.Context, rpc *UbusRPC, config string) (sections []S, err error) {
+	sectionType, ok := uci.SectionTypeOf[S](config)
+	if !ok {
+		var s S
+		return nil, fmt.Errorf("%T is not registered for config %s", s, config)
+	}
+
+	opts := UCIGetOptions{Config: config}
+	if !uci.IsSectionTypePattern(sectionType) {
+		opts.Type = sectionType
+	}
+	response, err := rpc.UCI().Get(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return nil, err
+	}
+	sections = make([]S, 0, len(result.Sections))
+	for _, section := range result.Sections {
+		if s, ok := section.(S); ok {
+			sections = append(sections, s)
+		}
+	}
+
+	return sections, nil
+}
+
+// returns the section name of config, which has to decode into S
+func GetSection[S uci.ConfigSection](ctx context.Context, rpc *UbusRPC, config, name string) (s S, err error) {
+	opts := UCIGetOptions{Config: config, Section: name}
+	response, err := rpc.UCI().Get(ctx, opts)
+	if err != nil {
+		return s, err
+	}
+
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return s, err
+	} else if len(result.Sections) == 0 {
+		return s, fmt.Errorf("section %s.%s not found", config, name)
+	}
+
+	s, ok := result.Sections[0].(S)
+	if !ok {
+		return s, fmt.Errorf("section %s.%s is a %T, not a %T", config, name, result.Sections[0], s)
+	}
+	return s, nil
+}
+
+// returns the section with the given .name
+func (u UCIGetResult) ByName(name string) (uci.ConfigSection, bool) {
+	for _, section := range u.Sections {
+		if section.GetName() == name {
+			return section, true
+		}
+	}
+	return nil, false
+}
+
+// returns all sections with the given .type, in order
+func (u UCIGetResult) ByType(sectionType string) []uci.ConfigSection {
+	return u.Filter(func(section uci.ConfigSection) bool {
+		return section.GetType() == sectionType
+	})
+}
+
+// returns all sections in which option is set to value, in order. for list options it is enough
+// for the list to contain value, e.g. ByOption("name", "wan") or ByOption("network", "lan")
+func (u UCIGetResult) ByOption(option, value string) []uci.ConfigSection {
+	return u.Filter(func(section uci.ConfigSection) bool {
+		values, err := uci.OptionValues(section)
+		return err == nil && slices.Contains(values[option], value)
+	})
+}
+
+// returns all sections for which match returns true, in order
+func (u UCIGetResult) Filter(match func(uci.ConfigSection) bool) (sections []uci.ConfigSection) {
+	for _, section := range u.Sections {
+		if match(section) {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
+// returns all sections keyed by .name
+func (u UCIGetResult) Index() map[string]uci.ConfigSection {
+	index := make(map[string]uci.ConfigSection, len(u.Sections))
+	for _, section := range u.Sections {
+		index[section.GetName()] = section
+	}
+	return index
+}
+
+// where MoveSection puts a section relative to another one
+type SectionPosition int
+
+const (
+	MoveBefore SectionPosition = iota
+	MoveAfter
+)
+
+// stages moving section of config right before or after anchor, e.g. to make a firewall rule
+// match before another one
+func MoveSection(ctx context.Context, rpc *UbusRPC, config, section string, position SectionPosition, anchor string) error {
+	names, err := sectionNames(ctx, rpc.UCI(), config)
+	if err != nil {
+		return err
+	}
+	names, err = moveName(names, section, position, anchor)
+	if err != nil {
+		return fmt.Errorf("%s: %w", config, err)
+	}
+
+	_, err = rpc.UCI().Order(ctx, UCIOrderOptions{Config: config, Sections: names})
+	return err
+}
+
+// returns names with section moved right before or after anchor
+func moveName(names []string, section string, position SectionPosition, anchor string) ([]string, error) {
+	if section == anchor {
+		return nil, fmt.Errorf("cannot move section %s relative to itself", section)
+	}
+	from := slices.Index(names, section)
+	if from < 0 {
+		return nil, fmt.Errorf("section %s not found", section)
+	}
+	names = slices.Delete(slices.Clone(names), from, from+1)
+
+	to := slices.Index(names, anchor)
+	if to < 0 {
+		return nil, fmt.Errorf("section %s not found", anchor)
+	}
+	if position == MoveAfter {
+		to++
+	}
+	return slices.Insert(names, to, section), nil
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+)
+
+func TestGetSections(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string {
+		switch string(call.Signature) {
+		case `{"config":"firewall","type":"zone"}`:
+			return `[0,{"values":{` +
+				`"lan":{".anonymous":false,".type":"zone",".name":"lan","name":"lan"},` +
+				`"wan":{".anonymous":false,".type":"zone",".name":"wan","name":"wan","masq":"1"}}}]`
+		case `{"config":"network"}`:
+			return `[0,{"values":{` +
+				`"wg0":{".anonymous":false,".type":"interface",".name":"wg0","proto":"wireguard"},` +
+				`"cfg01a1b2":{".anonymous":true,".type":"wireguard_wg0",".name":"cfg01a1b2","public_key":"a2V5MQ=="}}}]`
+		case `{"config":"firewall","type":"redirect"}`:
+			return `[0,{"values":{}}]`
+		}
+		return `[4]`
+	})
+
+	zones, err := GetSections[firewall.ZoneSection](context.Background(), rpc, firewall.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(zones, func(zone firewall.ZoneSection) bool { return zone.GetName() == "wan" })
+	if len(zones) != 2 || i < 0 {
+		t.Fatal("\nexpected: lan, wan", "\nactual: ", zones)
+	}
+	if masq, _ := zones[i].Masq.Get(); !masq {
+		t.Error("expected masq on wan")
+	}
+
+	// registered by pattern, so the whole config is fetched and filtered
+	peers, err := GetSections[network.WireGuardPeerSection](context.Background(), rpc, network.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peers) != 1 || peers[0].GetName() != "cfg01a1b2" {
+		t.Error("\nexpected: cfg01a1b2", "\nactual: ", peers)
+	}
+
+	redirects, err := GetSections[firewall.RedirectSection](context.Background(), rpc, firewall.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if redirects == nil || len(redirects) != 0 {
+		t.Error("\nexpected: an empty slice", "\nactual: ", redirects)
+	}
+
+	if _, err = GetSections[firewall.ZoneSection](context.Background(), rpc, "missing"); err == nil {
+		t.Error("expected an error for a model not registered for the config")
+	}
+	if _, err = GetSections[uci.GenericSection](context.Background(), rpc, firewall.Config); err == nil {
+		t.Error("expected an error for an unregistered section")
+	}
+
+	expected := []string{
+		`{"config":"firewall","type":"zone"}`,
+		`{"config":"network"}`,
+		`{"config":"firewall","type":"redirect"}`,
+	}
+	actual := make([]string, len(*calls))
+	for i, call := range *calls {
+		actual[i] = string(call.Signature)
+	}
+	if !slices.Equal(actual, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", actual)
+	}
+}
+
+// lan and wan zones, an anonymous forwarding and a rule with a multi-valued list option
+func testLookupResult() UCIGetResult {
+	return UCIGetResult{Sections: []uci.ConfigSection{
+		firewall.ZoneSection{
+			StaticSectionOptions: uci.StaticSectionOptions{Name: "lan", Type: firewall.Zone},
+			ZoneSectionOptions:   firewall.ZoneSectionOptions{Name: uci.Some("lan"), Network: uci.Some(uci.List{"lan", "guest"})},
+		},
+		firewall.ZoneSection{
+			StaticSectionOptions: uci.StaticSectionOptions{Name: "wan", Type: firewall.Zone},
+			ZoneSectionOptions:   firewall.ZoneSectionOptions{Name: uci.Some("wan"), Network: uci.Some(uci.List{"wan", "wan6"})},
+		},
+		uci.GenericSection{
+			StaticSectionOptions: uci.StaticSectionOptions{Anonymous: true, Name: "cfg02ad58", Type: "forwarding"},
+			Options:              uci.GenericSectionOptions{"src": {"lan"}, "dest": {"wan"}},
+		},
+		uci.GenericSection{
+			StaticSectionOptions: uci.StaticSectionOptions{Anonymous: true, Name: "cfg03ad58", Type: "forwarding"},
+			Options:              uci.GenericSectionOptions{"src": {"guest"}, "dest": {"wan"}},
+		},
+	}}
+}
+
+// the names of sections in order
+func sectionNamesOf(sections []uci.ConfigSection) []string {
+	names := []string{}
+	for _, section := range sections {
+		names = append(names, section.GetName())
+	}
+	return names
+}
+
+func TestByName(t *testing.T) {
+	result := testLookupResult()
+
+	tests := []struct {
+		name    string
+		section string
+		found   bool
+	}{
+		{"named", "wan", true},
+		{"anonymous", "cfg03ad58", true},
+		{"missing", "dmz", false},
+		{"option value is not a name", "guest", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			section, ok := result.ByName(test.section)
+			if ok != test.found {
+				t.Fatal("\nexpected found: ", test.found, "\nactual: ", ok)
+			}
+			if ok && section.GetName() != test.section {
+				t.Error("\nexpected: ", test.section, "\nactual: ", section.GetName())
+			} else if !ok && section != nil {
+				t.Error("expected no section, got", section)
+			}
+		})
+	}
+}
+
+func TestByType(t *testing.T) {
+	result := testLookupResult()
+
+	tests := []struct {
+		name        string
+		sectionType string
+		expected    []string
+	}{
+		{"typed", firewall.Zone, []string{"lan", "wan"}},
+		{"anonymous", "forwarding", []string{"cfg02ad58", "cfg03ad58"}},
+		{"missing", firewall.Rule, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := sectionNamesOf(result.ByType(test.sectionType)); !slices.Equal(actual, test.expected) {
+				t.Error("\nexpected: ", test.expected, "\nactual: ", actual)
+			}
+		})
+	}
+}
+
+func TestByOption(t *testing.T) {
+	result := testLookupResult()
+
+	tests := []struct {
+		name     string
+		option   string
+		value    string
+		expected []string
+	}{
+		{"typed option", "name", "wan", []string{"wan"}},
+		{"first value of a list", "network", "lan", []string{"lan"}},
+		{"later value of a list", "network", "wan6", []string{"wan"}},
+		{"generic option", "dest", "wan", []string{"cfg02ad58", "cfg03ad58"}},
+		{"generic option of one section", "src", "guest", []string{"cfg03ad58"}},
+		{"missing value", "network", "dmz", []string{}},
+		{"missing option", "masq", "1", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := sectionNamesOf(result.ByOption(test.option, test.value)); !slices.Equal(actual, test.expected) {
+				t.Error("\nexpected: ", test.expected, "\nactual: ", actual)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	result := testLookupResult()
+
+	tests := []struct {
+		name     string
+		match    func(uci.ConfigSection) bool
+		expected []string
+	}{
+		{"anonymous", func(s uci.ConfigSection) bool { return s.IsAnonymous() }, []string{"cfg02ad58", "cfg03ad58"}},
+		{"named", func(s uci.ConfigSection) bool { return !s.IsAnonymous() }, []string{"lan", "wan"}},
+		{"none", func(uci.ConfigSection) bool { return false }, []string{}},
+		{"all", func(uci.ConfigSection) bool { return true }, []string{"lan", "wan", "cfg02ad58", "cfg03ad58"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := sectionNamesOf(result.Filter(test.match)); !slices.Equal(actual, test.expected) {
+				t.Error("\nexpected: ", test.expected, "\nactual: ", actual)
+			}
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	index := testLookupResult().Index()
+
+	tests := []struct {
+		name        string
+		section     string
+		sectionType string
+	}{
+		{"named", "lan", firewall.Zone},
+		{"anonymous", "cfg02ad58", "forwarding"},
+		{"missing", "dmz", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			section, ok := index[test.section]
+			if ok != (test.sectionType != "") {
+				t.Fatal("unexpected presence of", test.section)
+			}
+			if ok && section.GetType() != test.sectionType {
+				t.Error("\nexpected: ", test.sectionType, "\nactual: ", section.GetType())
+			}
+		})
+	}
+	if len(index) != 4 {
+		t.Error("\nexpected: 4 sections", "\nactual: ", len(index))
+	}
+	if len((UCIGetResult{}).Index()) != 0 {
+		t.Error("expected an empty index for an empty result")
+	}
+}
+
+func TestGetSection(t *testing.T) {
+	rpc, _ := newFakeRPC(t, func(call fakeCall) string {
+		switch string(call.Signature) {
+		case `{"config":"firewall","section":"lan"}`:
+			return `[0,{"values":{".anonymous":false,".type":"zone",".name":"lan","name":"lan","network":["lan"]}}]`
+		case `{"config":"firewall","section":"defaults"}`:
+			return `[0,{"values":{".anonymous":true,".type":"defaults",".name":"cfg01e63d","input":"REJECT"}}]`
+		}
+		return `[4]`
+	})
+
+	zone, err := GetSection[firewall.ZoneSection](context.Background(), rpc, firewall.Config, "lan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if networks, _ := zone.Network.Get(); zone.GetName() != "lan" || !slices.Equal(networks, uci.List{"lan"}) {
+		t.Error("unexpected zone:", zone)
+	}
+
+	if _, err = GetSection[firewall.ZoneSection](context.Background(), rpc, firewall.Config, "defaults"); err == nil {
+		t.Error("expected an error for a section of another type")
+	}
+	if _, err = GetSection[firewall.ZoneSection](context.Background(), rpc, firewall.Config, "missing"); err == nil {
+		t.Error("expected an error for a missing section")
+	}
+}
+
+func TestMoveName(t *testing.T) {
+	names := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name     string
+		section  string
+		position SectionPosition
+		anchor   string
+		expected []string
+	}{
+		{"before first", "c", MoveBefore, "a", []string{"c", "a", "b", "d"}},
+		{"after last", "a", MoveAfter, "d", []string{"b", "c", "d", "a"}},
+		{"before next", "b", MoveBefore, "c", []string{"a", "b", "c", "d"}},
+		{"after previous", "d", MoveAfter, "b", []string{"a", "b", "d", "c"}},
+		{"self", "b", MoveAfter, "b", nil},
+		{"missing section", "x", MoveBefore, "a", nil},
+		{"missing anchor", "a", MoveBefore, "x", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := moveName(names, test.section, test.position, test.anchor)
+			if test.expected == nil {
+				if err == nil {
+					t.Error("expected an error, got", actual)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			} else if !slices.Equal(actual, test.expected) {
+				t.Error("\nexpected: ", test.expected, "\nactual: ", actual)
+			}
+		})
+	}
+
+	if !slices.Equal(names, []string{"a", "b", "c", "d"}) {
+		t.Error("names was modified:", names)
+	}
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+// the "version" field of snapshot files. version 1 is a single JSON object with the creation time
+// and every config as a flat list of its raw sections, in order. Snapshot.UnmarshalJSON refuses
+// files of a newer version
+const SnapshotVersion = 1
+
+// the state of several configs at one point in time, e.g. a baseline to detect drift against.
+// marshals to a versioned JSON file, see SnapshotVersion
+//
+// implements json.Marshaler and json.Unmarshaler
+type Snapshot struct {
+	Created time.Time
+	Configs map[string]UCIGetResult
+}
+
+// the on-disk format of a Snapshot. sections are kept raw so they can be decoded with the models
+// registered for their config
+type snapshotFile struct {
+	Version int                          `json:"version"`
+	Created time.Time                    `json:"created"`
+	Configs map[string][]json.RawMessage `json:"configs"`
+}
+
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	file := snapshotFile{Version: SnapshotVersion, Created: s.Created, Configs: make(map[string][]json.RawMessage, len(s.Configs))}
+	for config, result := range s.Configs {
+		sections, err := marshalSections(config, result)
+		if err != nil {
+			return nil, err
+		}
+		file.Configs[config] = sections
+	}
+
+	return json.Marshal(file)
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) (err error) {
+	var file snapshotFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return err
+	} else if file.Version < 1 || file.Version > SnapshotVersion {
+		return fmt.Errorf("unsupported snapshot version %d", file.Version)
+	}
+
+	s.Created = file.Created
+	s.Configs = make(map[string]UCIGetResult, len(file.Configs))
+	for config, raw := range file.Configs {
+		if s.Configs[config], err = unmarshalSections(config, raw); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// marshals every section of result on its own, typed sections flatten their options like rpcd does
+func marshalSections(config string, result UCIGetResult) ([]json.RawMessage, error) {
+	sections := make([]json.RawMessage, 0, len(result.Sections))
+	for _, section := range result.Sections {
+		data, err := json.Marshal(section)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", config, section.GetName(), err)
+		}
+		sections = append(sections, data)
+	}
+	return sections, nil
+}
+
+// decodes sections written by marshalSections, see uci.UnmarshalSectionLossless
+func unmarshalSections(config string, raw []json.RawMessage) (result UCIGetResult, err error) {
+	for i, data := range raw {
+		section, err := uci.UnmarshalSectionLossless(config, data)
+		if err != nil {
+			return result, fmt.Errorf("%s: section %d: %w", config, i, err)
+		}
+		result.Sections = append(result.Sections, section)
+	}
+	return result, nil
+}
+
+// like UCIGetOptions.GetResult for a whole config, but with uci.UnmarshalSectionLossless
+func losslessResult(config string, p Response) (result UCIGetResult, err error) {
+	if len(p) < 2 {
+		return result, errors.New("empty response")
+	}
+	values, ok := p[1].(valuesResult)
+	if !ok {
+		return result, errors.New("not a UCIGetResult")
+	}
+
+	for _, name := range values.sectionNames() {
+		section, err := uci.UnmarshalSectionLossless(config, values.Values[name])
+		if err != nil {
+			return result, err
+		}
+		result.Sections = append(result.Sections, section)
+	}
+	sort.SliceStable(result.Sections, func(i, j int) bool {
+		return result.Sections[i].GetIndex() < result.Sections[j].GetIndex()
+	})
+	return result, nil
+}
+
+// returns the names of all configs in the snapshot, sorted
+func (s Snapshot) ConfigNames() []string {
+	configs := make([]string, 0, len(s.Configs))
+	for config := range s.Configs {
+		configs = append(configs, config)
+	}
+	sort.Strings(configs)
+	return configs
+}
+
+// reads the given configs from the router, or every config `uci configs` lists if there are
+// none. staged changes of this session are included. configs which do not exist are left out.
+// sections are only typed if their model keeps every option, see uci.UnmarshalSectionLossless
+func TakeSnapshot(ctx context.Context, rpc *UbusRPC, configs ...string) (s Snapshot, err error) {
+	if len(configs) == 0 {
+		opts := UCIConfigsOptions{}
+		response, err := rpc.UCI().Configs(ctx, opts)
+		if err != nil {
+			return s, err
+		}
+		result, err := opts.GetResult(response)
+		if err != nil {
+			return s, err
+		}
+		configs = result.Configs
+	}
+
+	s.Created = time.Now().UTC()
+	s.Configs = make(map[string]UCIGetResult, len(configs))
+	for _, config := range configs {
+		response, err := rpc.UCI().Get(ctx, UCIGetOptions{Config: config})
+		if len(response) > 0 && response[0] == ExitCodeNotFound {
+			continue
+		} else if err != nil {
+			return s, fmt.Errorf("%s: %w", config, err)
+		}
+		result, err := losslessResult(config, response)
+		if err != nil {
+			return s, fmt.Errorf("%s: %w", config, err)
+		}
+		s.Configs[config] = result
+	}
+
+	return s, nil
+}
+
+// the differences between two snapshots keyed by config, configs without differences are left out
+type SnapshotDiff map[string]UCIDiff
+
+// returns what changed from s to other. a config missing from either snapshot is compared as
+// if it had no sections
+func (s Snapshot) Diff(other Snapshot) (SnapshotDiff, error) {
+	configs := s.ConfigNames()
+	for _, config := range other.ConfigNames() {
+		if _, ok := s.Configs[config]; !ok {
+			configs = append(configs, config)
+		}
+	}
+
+	diff := make(SnapshotDiff)
+	for _, config := range configs {
+		d, err := s.Configs[config].Diff(other.Configs[config])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", config, err)
+		}
+		if !d.Empty() {
+			diff[config] = d
+		}
+	}
+
+	return diff, nil
+}
+
+// compares the router with baseline and returns everything that drifted from it. only the
+// configs in baseline are read, a baseline without configs cannot drift and reads nothing
+func Drift(ctx context.Context, rpc *UbusRPC, baseline Snapshot) (SnapshotDiff, error) {
+	if len(baseline.Configs) == 0 {
+		return SnapshotDiff{}, nil
+	}
+	live, err := TakeSnapshot(ctx, rpc, baseline.ConfigNames()...)
+	if err != nil {
+		return nil, err
+	}
+	return baseline.Diff(live)
+}
+
+// reports whether the snapshots are identical
+func (d SnapshotDiff) Empty() bool {
+	return len(d) == 0
+}
+
+// renders the diff of every config under a header with its name, see UCIDiff.String
+func (d SnapshotDiff) String() string {
+	var b strings.Builder
+
+	configs := make([]string, 0, len(d))
+	for config := range d {
+		configs = append(configs, config)
+	}
+	sort.Strings(configs)
+
+	for i, config := range configs {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%s:\n%s", config, d[config])
+	}
+
+	return b.String()
+}
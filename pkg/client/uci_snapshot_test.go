@@ -0,0 +1,150 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
+)
+
+func testSnapshot(t *testing.T) Snapshot {
+	t.Helper()
+	return Snapshot{
+		Created: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC),
+		Configs: map[string]UCIGetResult{
+			"firewall": currentResult(t),
+			// a config without a registered model
+			"custom": {Sections: []uci.ConfigSection{
+				genericSection(t, `{".anonymous":false,".type":"thing",".name":"b","value":"1"}`),
+				genericSection(t, `{".anonymous":true,".type":"thing",".name":"cfg02c8f1","list":["x","y"]}`),
+			}},
+		},
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	s := testSnapshot(t)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `{"version":1,`) {
+		t.Error("unexpected snapshot file:", string(data))
+	}
+
+	var actual Snapshot
+	if err = json.Unmarshal(data, &actual); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(actual, s) {
+		t.Error("\nexpected: ", s, "\nactual: ", actual)
+	}
+	if names := actual.ConfigNames(); !slices.Equal(names, []string{"custom", "firewall"}) {
+		t.Error("\nexpected: [custom firewall]", "\nactual: ", names)
+	}
+}
+
+func TestSnapshotUnmarshalErrors(t *testing.T) {
+	for name, data := range map[string]string{
+		"no version":      `{"configs":{}}`,
+		"newer version":   `{"version":99,"configs":{}}`,
+		"invalid section": `{"version":1,"configs":{"network":["{"]}}`,
+		"not an object":   `[]`,
+	} {
+		var s Snapshot
+		if err := json.Unmarshal([]byte(data), &s); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	baseline := testSnapshot(t)
+
+	changed := testSnapshot(t)
+	zones := changed.Configs["firewall"]
+	zones.Sections = slices.Clone(zones.Sections)
+	lan := zones.Sections[1].(firewall.ZoneSection)
+	lan.Input = uci.Some("REJECT")
+	zones.Sections[1] = lan
+	changed.Configs["firewall"] = zones
+	delete(changed.Configs, "custom")
+	changed.Configs["dhcp"] = UCIGetResult{Sections: []uci.ConfigSection{
+		genericSection(t, `{".anonymous":false,".type":"dnsmasq",".name":"main","domain":"lan"}`),
+	}}
+
+	diff, err := baseline.Diff(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "custom:\n- b (thing)\n    - value='1'\n- cfg02c8f1 (thing)\n    - list='x y'\n\n" +
+		"dhcp:\n+ main (dnsmasq)\n    + domain='lan'\n\n" +
+		"firewall:\n~ lan (zone)\n    ~ input='ACCEPT' -> 'REJECT'\n"
+	if actual := diff.String(); actual != expected {
+		t.Errorf("\nexpected:\n%s\nactual:\n%s", expected, actual)
+	}
+	if d := diff["firewall"]; len(d.Sections) != 1 || d.Sections[0].Name != "lan" {
+		t.Error("\nexpected: a change of lan", "\nactual: ", d)
+	}
+
+	if diff, err = baseline.Diff(testSnapshot(t)); err != nil {
+		t.Fatal(err)
+	} else if !diff.Empty() {
+		t.Error("expected no differences, got", diff)
+	}
+}
+
+func TestDrift(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string {
+		if string(call.Signature) == `{"config":"firewall"}` {
+			return strings.Replace(currentFirewall, `"input":"ACCEPT"`, `"input":"REJECT"`, 1)
+		}
+		return `[4]`
+	})
+
+	baseline := Snapshot{Configs: map[string]UCIGetResult{"firewall": currentResult(t)}}
+	diff, err := Drift(context.Background(), rpc, baseline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := diff["firewall"]; len(diff) != 1 || len(d.Sections) != 1 || d.Sections[0].Name != "lan" {
+		t.Error("\nexpected: a change of lan", "\nactual: ", diff)
+	}
+	// only the configs of the baseline are read
+	if actual := procedures(*calls); !slices.Equal(actual, []string{"uci.get"}) {
+		t.Error("unexpected calls:", actual)
+	}
+
+	*calls = nil
+	if diff, err = Drift(context.Background(), rpc, Snapshot{}); err != nil {
+		t.Fatal(err)
+	} else if !diff.Empty() {
+		t.Error("expected no drift from an empty baseline, got", diff)
+	}
+	if len(*calls) != 0 {
+		t.Error("expected no calls for an empty baseline, got", procedures(*calls))
+	}
+}
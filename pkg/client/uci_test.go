@@ -0,0 +1,141 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestModifiedList(t *testing.T) {
+	appendWAN := func(list uci.List) uci.List { return append(list, "wan") }
+	current := uci.List{"lan"}
+
+	list, changed, err := modifiedList(current, nil, appendWAN)
+	if err != nil || !changed || !slices.Equal(list, uci.List{"lan", "wan"}) {
+		t.Error("unexpected result without expected list:", list, changed, err)
+	}
+	if !slices.Equal(current, uci.List{"lan"}) {
+		t.Error("current list was modified:", current)
+	}
+
+	list, changed, err = modifiedList(current, &uci.List{"lan"}, appendWAN)
+	if err != nil || !changed || !slices.Equal(list, uci.List{"lan", "wan"}) {
+		t.Error("unexpected result with matching expected list:", list, changed, err)
+	}
+
+	// someone else added guest since the caller read the list
+	if _, _, err = modifiedList(uci.List{"lan", "guest"}, &current, appendWAN); !errors.Is(err, ErrListConflict) {
+		t.Error("expected ErrListConflict, got", err)
+	}
+
+	_, changed, err = modifiedList(uci.List{"wan"}, nil, func(list uci.List) uci.List { return list })
+	if err != nil || changed {
+		t.Error("expected no change:", changed, err)
+	}
+}
+
+func TestEmptyValues(t *testing.T) {
+	var response Response
+	if err := json.Unmarshal([]byte(`[0,{"values":{}}]`), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := UCIGetOptions{Config: "firewall", Type: "redirect"}.GetResult(response)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(result.Sections) != 0 {
+		t.Error("expected no sections, got", result.Sections)
+	}
+	if result, err = losslessResult("firewall", response); err != nil {
+		t.Fatal(err)
+	} else if len(result.Sections) != 0 {
+		t.Error("expected no sections, got", result.Sections)
+	}
+}
+
+func TestSectionOrder(t *testing.T) {
+	// rpcd sends the sections in the order of the config file, which is not sorted by name
+	data := `[0,{"values":{` +
+		`"wan":{".anonymous":false,".type":"zone",".name":"wan"},` +
+		`"cfg02dc81":{".anonymous":true,".type":"forwarding",".name":"cfg02dc81"},` +
+		`"lan":{".anonymous":false,".type":"zone",".name":"lan"}}}]`
+	var response Response
+	if err := json.Unmarshal([]byte(data), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"wan", "cfg02dc81", "lan"}
+	values := response[1].(valuesResult)
+	if names := values.sectionNames(); !slices.Equal(names, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", names)
+	}
+	result, err := UCIGetOptions{Config: "firewall"}.GetResult(response)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := []string{}
+	for _, section := range result.Sections {
+		names = append(names, section.GetName())
+	}
+	if !slices.Equal(names, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", names)
+	}
+
+	// marshaling keeps the order too
+	marshaled, err := json.Marshal(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys, err := objectKeys(marshaled); err != nil || !slices.Equal(keys, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", keys, err)
+	}
+
+	// without a known order the names are sorted
+	values.names = nil
+	if names := values.sectionNames(); !slices.Equal(names, []string{"cfg02dc81", "lan", "wan"}) {
+		t.Error("expected sorted names, got", names)
+	}
+}
+
+func TestObjectKeys(t *testing.T) {
+	keys, err := objectKeys([]byte(`{"b":{"x":[1,2]},"a":"1","c":null}`))
+	if err != nil {
+		t.Fatal(err)
+	} else if !slices.Equal(keys, []string{"b", "a", "c"}) {
+		t.Error("unexpected keys:", keys)
+	}
+	if _, err = objectKeys([]byte(`{"a":`)); err == nil {
+		t.Error("expected an error for truncated JSON")
+	}
+}
+
+func TestAddResult(t *testing.T) {
+	named := UCIAddOptions{Config: "firewall", Type: "zone", Name: "lan"}
+	if result, err := named.GetResult(Response{ExitCodeOK}); err != nil || result.Section != "lan" {
+		t.Error("unexpected result:", result, err)
+	}
+	for _, response := range []Response{{}, {addResult{}}, {ExitCodeNotFound}} {
+		if _, err := named.GetResult(response); err == nil {
+			t.Errorf("expected an error for %v", response)
+		}
+	}
+}
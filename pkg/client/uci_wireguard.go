@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+)
+
+// returns all peers of the WireGuard interface iface, including staged changes. an interface
+// without peers has an empty slice
+func GetWireGuardPeers(ctx context.Context, rpc *UbusRPC, iface string) (peers []network.WireGuardPeerSection, err error) {
+	opts := UCIGetOptions{Config: network.Config, Type: network.WireGuardPeerType(iface)}
+	response, err := rpc.UCI().Get(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return nil, err
+	}
+	peers = make([]network.WireGuardPeerSection, 0, len(result.Sections))
+	for _, section := range result.Sections {
+		if peer, ok := section.(network.WireGuardPeerSection); ok {
+			peers = append(peers, peer)
+		}
+	}
+
+	return peers, nil
+}
+
+// stages a new peer of the WireGuard interface iface and returns the name of its section
+func AddWireGuardPeer(ctx context.Context, rpc *UbusRPC, iface string, peer network.WireGuardPeerSectionOptions) (string, error) {
+	opts := UCIAddOptions{Config: network.Config, Type: network.WireGuardPeerType(iface), Values: peer}
+	response, err := rpc.UCI().Add(ctx, opts)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := opts.GetResult(response)
+	if err != nil {
+		return "", err
+	}
+	return result.Section, nil
+}
+
+// stages the removal of every peer of the WireGuard interface iface with the given public key
+func RemoveWireGuardPeer(ctx context.Context, rpc *UbusRPC, iface, publicKey string) error {
+	peers, err := GetWireGuardPeers(ctx, rpc, iface)
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	for _, peer := range peers {
+		if key, _ := peer.PublicKey.Get(); key != publicKey {
+			continue
+		}
+		if _, err = rpc.UCI().Delete(ctx, UCIDeleteOptions{Config: network.Config, Section: peer.Name}); err != nil {
+			return err
+		}
+		removed = true
+	}
+	if !removed {
+		return fmt.Errorf("no peer with public key %s on %s", publicKey, iface)
+	}
+
+	return nil
+}
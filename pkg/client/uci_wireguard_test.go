@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
+)
+
+const testWireGuardPeers = `[0,{"values":{` +
+	`"cfg01a1b2":{".anonymous":true,".type":"wireguard_wg0",".name":"cfg01a1b2","public_key":"a2V5MQ==","allowed_ips":["10.0.0.2/32"]},` +
+	`"cfg02a1b2":{".anonymous":true,".type":"wireguard_wg0",".name":"cfg02a1b2","public_key":"a2V5Mg=="},` +
+	`"cfg03a1b2":{".anonymous":true,".type":"wireguard_wg0",".name":"cfg03a1b2","public_key":"a2V5MQ=="}}}]`
+
+func TestGetWireGuardPeers(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string { return testWireGuardPeers })
+
+	peers, err := GetWireGuardPeers(context.Background(), rpc, "wg0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := string((*calls)[0].Signature); actual != `{"config":"network","type":"wireguard_wg0"}` {
+		t.Error("unexpected signature:", actual)
+	}
+	if len(peers) != 3 {
+		t.Fatal("\nexpected: 3 peers", "\nactual: ", peers)
+	}
+	for _, peer := range peers {
+		if peer.Interface() != "wg0" {
+			t.Error("\nexpected: wg0", "\nactual: ", peer.Interface())
+		}
+	}
+	i := slices.IndexFunc(peers, func(peer network.WireGuardPeerSection) bool { return peer.Name == "cfg01a1b2" })
+	if i < 0 {
+		t.Fatal("missing peer cfg01a1b2")
+	}
+	if actual, _ := peers[i].AllowedIPs.Get(); !slices.Equal(actual, uci.List{"10.0.0.2/32"}) {
+		t.Error("\nexpected: ", uci.List{"10.0.0.2/32"}, "\nactual: ", actual)
+	}
+}
+
+func TestGetWireGuardPeersNone(t *testing.T) {
+	rpc, _ := newFakeRPC(t, func(call fakeCall) string { return `[0,{"values":{}}]` })
+
+	peers, err := GetWireGuardPeers(context.Background(), rpc, "wg0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peers == nil || len(peers) != 0 {
+		t.Error("\nexpected: an empty slice", "\nactual: ", peers)
+	}
+}
+
+func TestAddWireGuardPeer(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string { return `[0,{"section":"cfg04a1b2"}]` })
+
+	peer := network.WireGuardPeerSectionOptions{PublicKey: uci.Some("a2V5Mw==")}
+	name, err := AddWireGuardPeer(context.Background(), rpc, "wg0", peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "cfg04a1b2" {
+		t.Error("\nexpected: cfg04a1b2", "\nactual: ", name)
+	}
+	if actual := string((*calls)[0].Signature); actual != `{"config":"network","type":"wireguard_wg0","values":{"public_key":"a2V5Mw=="}}` {
+		t.Error("unexpected signature:", actual)
+	}
+}
+
+func TestRemoveWireGuardPeer(t *testing.T) {
+	rpc, calls := newFakeRPC(t, func(call fakeCall) string {
+		if call.Procedure == "get" {
+			return testWireGuardPeers
+		}
+		return `[0]`
+	})
+
+	if err := RemoveWireGuardPeer(context.Background(), rpc, "wg0", "a2V5MQ=="); err != nil {
+		t.Fatal(err)
+	}
+	if actual := procedures(*calls); !slices.Equal(actual, []string{"uci.get", "uci.delete", "uci.delete"}) {
+		t.Fatal("unexpected calls:", actual)
+	}
+	var deleted []string
+	for _, call := range (*calls)[1:] {
+		deleted = append(deleted, string(call.Signature))
+	}
+	slices.Sort(deleted)
+	expected := []string{`{"config":"network","section":"cfg01a1b2"}`, `{"config":"network","section":"cfg03a1b2"}`}
+	if !slices.Equal(deleted, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", deleted)
+	}
+
+	if err := RemoveWireGuardPeer(context.Background(), rpc, "wg0", "bWlzc2luZw=="); err == nil {
+		t.Error("expected an error for a missing peer")
+	}
+}
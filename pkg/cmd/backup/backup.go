@@ -0,0 +1,153 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/client"
+)
+
+// the PreRun of backup and restore
+func loadClient(c *cobra.Command, args []string) {
+	c.SetContext(client.LoadToContext(c.Context()))
+}
+
+func NewBackupCommand() *cobra.Command {
+	o := BackupOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:    "backup",
+		Short:  "Back up every config.",
+		Long:   "Back up every config of the router together with its board and firmware info into a tar.gz archive.",
+		Args:   cobra.MaximumNArgs(numOptions),
+		PreRun: loadClient,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type BackupOptions struct {
+	File string
+}
+
+func (o *BackupOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "Where to write the archive.")
+	c.MarkFlagRequired("file")
+}
+
+func (o *BackupOptions) Run(c *cobra.Command) (err error) {
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	backup, err := client.TakeBackup(ctx, rpc)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(o.File, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if err = backup.Write(f); err != nil {
+		return err
+	}
+
+	board := backup.Metadata.Board
+	fmt.Printf("backed up %d configs of %s (%s, %s)\n", len(backup.Configs), board.Hostname, board.Model, board.Release.Description)
+	return nil
+}
+
+func NewRestoreCommand() *cobra.Command {
+	o := RestoreOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore configs from a backup.",
+		Long: "Stage the changes which restore the configs of a backup made with `gur backup` and apply them " +
+			"with rollback.",
+		Args:   cobra.MaximumNArgs(numOptions),
+		PreRun: loadClient,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type RestoreOptions struct {
+	File    string
+	Configs []string
+	DryRun  bool
+	Timeout int
+}
+
+func (o *RestoreOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "The archive to restore.")
+	c.Flags().StringSliceVarP(&o.Configs, "configs", "c", nil, "Which configs to restore, all of them by default.")
+	c.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "Only print the changes, do not apply them.")
+	c.Flags().IntVarP(&o.Timeout, "timeout", "", 30, "Seconds until the changes are rolled back if the router becomes unreachable.")
+	c.MarkFlagRequired("file")
+}
+
+func (o *RestoreOptions) Run(c *cobra.Command) error {
+	f, err := os.Open(o.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	backup, err := client.ReadBackup(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", o.File, err)
+	}
+
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	plan, skipped, err := backup.Plan(ctx, rpc, o.Configs...)
+	if err != nil {
+		return err
+	}
+	for _, config := range skipped {
+		fmt.Fprintf(os.Stderr, "skipping %s, it does not exist on the router\n", config)
+	}
+	if plan.Empty() {
+		fmt.Println("no changes")
+		return nil
+	}
+	fmt.Print(plan)
+	if o.DryRun {
+		return nil
+	}
+
+	return plan.Apply(ctx, rpc, client.PlanApplyOptions{Timeout: o.Timeout, Check: client.CheckReachable(rpc)})
+}
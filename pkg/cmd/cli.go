@@ -19,7 +19,9 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/cmd/backup"
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/cmd/login"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/cmd/snapshot"
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/cmd/uci"
 )
 
@@ -31,7 +33,10 @@ func NewCommand() *cobra.Command {
 	}
 
 	c.AddCommand(
+		backup.NewBackupCommand(),
 		login.NewLoginCommand(),
+		backup.NewRestoreCommand(),
+		snapshot.NewSnapshotCommand(),
 		uci.NewUCICommand(),
 	)
 
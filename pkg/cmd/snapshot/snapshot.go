@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/spf13/cobra"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/client"
+)
+
+func NewSnapshotCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Save and compare config snapshots.",
+		Long:  "Save the router's configs to a file and compare the router against it later to detect drift.",
+		PersistentPreRun: func(c *cobra.Command, args []string) {
+			c.SetContext(client.LoadToContext(c.Context()))
+		},
+	}
+
+	c.AddCommand(
+		NewDiffCommand(),
+		NewSaveCommand(),
+	)
+
+	return c
+}
+
+func NewDiffCommand() *cobra.Command {
+	o := DiffOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare the router against a snapshot.",
+		Args:  cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type DiffOptions struct {
+	File   string
+	Output string
+}
+
+func (o *DiffOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "The snapshot to compare against.")
+	c.Flags().StringVarP(&o.Output, "output", "o", "text", "Output format, text or json.")
+	c.MarkFlagRequired("file")
+}
+
+func (o *DiffOptions) Run(c *cobra.Command) error {
+	if o.Output != "text" && o.Output != "json" {
+		return fmt.Errorf("invalid output format: %q", o.Output)
+	}
+
+	data, err := os.ReadFile(o.File)
+	if err != nil {
+		return err
+	}
+	var baseline client.Snapshot
+	if err = json.Unmarshal(data, &baseline); err != nil {
+		return fmt.Errorf("%s: %w", o.File, err)
+	}
+
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	diff, err := client.Drift(ctx, rpc, baseline)
+	if err != nil {
+		return err
+	}
+
+	if o.Output == "json" {
+		output, _ := json.MarshalIndent(diff, "", "  ")
+		fmt.Println(string(output))
+	} else if diff.Empty() {
+		fmt.Println("no drift")
+	} else {
+		fmt.Print(diff)
+	}
+	return nil
+}
+
+func NewSaveCommand() *cobra.Command {
+	o := SaveOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "save",
+		Short: "Save the router's configs to a snapshot.",
+		Args:  cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type SaveOptions struct {
+	File    string
+	Configs []string
+}
+
+func (o *SaveOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "Where to write the snapshot.")
+	c.Flags().StringSliceVarP(&o.Configs, "configs", "c", nil, "Which configs to save, all of them by default.")
+	c.MarkFlagRequired("file")
+}
+
+func (o *SaveOptions) Run(c *cobra.Command) error {
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	snapshot, err := client.TakeSnapshot(ctx, rpc, o.Configs...)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(o.File, append(output, '\n'), 0o600)
+}
@@ -17,33 +17,26 @@ limitations under the License.
 package uci
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
-	"slices"
+	"regexp"
 
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/client"
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
 )
 
+// same charset libuci accepts for config names
+var configName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// configs outside uci.Configs are allowed, their sections are handled as uci.GenericSection
 func checkConfig(c string) error {
-	if !slices.Contains(uci.Configs, c) {
-		return fmt.Errorf("invalid config option, must be one of: %s", uci.Configs)
+	if !configName.MatchString(c) {
+		return fmt.Errorf("invalid config name: %q", c)
 	} else {
 		return nil
 	}
 }
 
-func unmarshalCLIValues[S uci.ConfigSectionOptions](o *SetOptions) (u client.UCISetOptions) {
-	var s S
-	err := json.Unmarshal([]byte(o.Values), &s)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	u = client.UCISetOptions{
-		Config:  o.Config,
-		Section: o.Section,
-		Values:  s,
-	}
-	return u
+// parses the JSON-formatted values passed on the command line into the typed options registered
+// for the given section type of config, or into uci.GenericSectionOptions if there are none
+func unmarshalCLIOptions(config, sectionType, values string) (uci.ConfigSectionOptions, error) {
+	return uci.UnmarshalSectionOptions(config, sectionType, []byte(values))
 }
@@ -19,20 +19,16 @@ package uci
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/client"
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dhcp"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/dropbear"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/network"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/system"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/uhttpd"
-	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/wireless"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/uciformat"
 )
 
 func NewUCICommand() *cobra.Command {
@@ -41,23 +37,22 @@ func NewUCICommand() *cobra.Command {
 		Short: "Run UCI commands.",
 		Long:  "Run UCI commands to update router configs.",
 		PersistentPreRun: func(c *cobra.Command, args []string) {
-			rpc := client.UbusRPC{}
-			configFile, err := rpc.Load()
-			if err != nil {
-				log.Fatalln(configFile, "not found, you should run `gur login`!")
-			}
-			ctx := client.AddToContext(c.Context(), rpc)
-			c.SetContext(ctx)
+			c.SetContext(client.LoadToContext(c.Context()))
 		},
 	}
 
 	c.AddCommand(
 		NewAddCommand(),
+		NewAddListCommand(),
 		NewApplyCommand(),
 		NewChangesCommand(),
 		NewConfigsCommand(),
+		NewDelListCommand(),
 		NewDeleteCommand(),
 		NewGetCommand(),
+		NewImportCommand(),
+		NewOrderCommand(),
+		NewReconcileCommand(),
 		NewRevertCommand(),
 		NewSetCommand(),
 	)
@@ -85,11 +80,15 @@ func NewAddCommand() *cobra.Command {
 type AddOptions struct {
 	Config string
 	Type   string
+	Name   string
+	Values string // '{"enabled": "1"}'
 }
 
 func (o *AddOptions) BindFlags(c *cobra.Command) {
 	c.Flags().StringVarP(&o.Config, "config", "c", "", "Which config to query.")
 	c.Flags().StringVarP(&o.Type, "type", "t", "", "The type of the config section.")
+	c.Flags().StringVarP(&o.Name, "name", "n", "", "The name of the new section, anonymous if not set.")
+	c.Flags().StringVarP(&o.Values, "values", "v", "", "The initial option: value pairs, must be JSON-formatted.")
 	c.MarkFlagRequired("config")
 	c.MarkFlagRequired("type")
 }
@@ -99,6 +98,13 @@ func (o *AddOptions) Run(c *cobra.Command) (err error) {
 		uciAddOpts := client.UCIAddOptions{
 			Config: o.Config,
 			Type:   o.Type,
+			Name:   o.Name,
+		}
+		if o.Values != "" {
+			uciAddOpts.Values, err = unmarshalCLIOptions(o.Config, o.Type, o.Values)
+			if err != nil {
+				return err
+			}
 		}
 		ctx := c.Context()
 		rpc := client.GetFromContext(c.Context())
@@ -116,6 +122,62 @@ func (o *AddOptions) Run(c *cobra.Command) (err error) {
 	return err
 }
 
+func NewAddListCommand() *cobra.Command {
+	o := AddListOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "add-list",
+		Short: "Append values to a list option.",
+		Args:  cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type AddListOptions struct {
+	Config  string
+	Section string
+	Option  string
+	Values  []string
+}
+
+func (o *AddListOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.Config, "config", "c", "", "Which config to query.")
+	c.Flags().StringVarP(&o.Section, "section", "s", "", "The section of the config.")
+	c.Flags().StringVarP(&o.Option, "option", "o", "", "The list option within the config section.")
+	c.Flags().StringSliceVarP(&o.Values, "values", "v", nil, "The values to append to the list.")
+	c.MarkFlagRequired("config")
+	c.MarkFlagRequired("section")
+	c.MarkFlagRequired("option")
+	c.MarkFlagRequired("values")
+}
+
+func (o *AddListOptions) Run(c *cobra.Command) (err error) {
+	if err = checkConfig(o.Config); err == nil {
+		uciAddListOpts := client.UCIAddListOptions{
+			Config:  o.Config,
+			Section: o.Section,
+			Option:  o.Option,
+			Values:  o.Values,
+		}
+		ctx := c.Context()
+		rpc := client.GetFromContext(c.Context())
+		response, err := rpc.UCI().AddList(ctx, uciAddListOpts)
+		if err != nil {
+			return err
+		}
+
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	}
+	return err
+}
+
 func NewApplyCommand() *cobra.Command {
 	o := ApplyOptions{}
 	structType := reflect.TypeOf(o)
@@ -179,10 +241,12 @@ func NewChangesCommand() *cobra.Command {
 
 type ChangesOptions struct {
 	Config string
+	Diff   bool
 }
 
 func (o *ChangesOptions) BindFlags(c *cobra.Command) {
 	c.Flags().StringVarP(&o.Config, "config", "c", "", "Which config to query.")
+	c.Flags().BoolVarP(&o.Diff, "diff", "d", false, "Print the changes as a human-readable diff instead of JSON.")
 }
 
 func (o *ChangesOptions) Run(c *cobra.Command) (err error) {
@@ -200,6 +264,10 @@ func (o *ChangesOptions) Run(c *cobra.Command) (err error) {
 		if err != nil {
 			return err
 		}
+		if o.Diff {
+			fmt.Print(result.Diff())
+			return nil
+		}
 		output, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(output))
 	}
@@ -241,6 +309,62 @@ func (o *ConfigsOptions) Run(c *cobra.Command) error {
 	return err
 }
 
+func NewDelListCommand() *cobra.Command {
+	o := DelListOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "del-list",
+		Short: "Remove values from a list option.",
+		Args:  cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type DelListOptions struct {
+	Config  string
+	Section string
+	Option  string
+	Values  []string
+}
+
+func (o *DelListOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.Config, "config", "c", "", "Which config to query.")
+	c.Flags().StringVarP(&o.Section, "section", "s", "", "The section of the config.")
+	c.Flags().StringVarP(&o.Option, "option", "o", "", "The list option within the config section.")
+	c.Flags().StringSliceVarP(&o.Values, "values", "v", nil, "The values to remove from the list.")
+	c.MarkFlagRequired("config")
+	c.MarkFlagRequired("section")
+	c.MarkFlagRequired("option")
+	c.MarkFlagRequired("values")
+}
+
+func (o *DelListOptions) Run(c *cobra.Command) (err error) {
+	if err = checkConfig(o.Config); err == nil {
+		uciDelListOpts := client.UCIDelListOptions{
+			Config:  o.Config,
+			Section: o.Section,
+			Option:  o.Option,
+			Values:  o.Values,
+		}
+		ctx := c.Context()
+		rpc := client.GetFromContext(c.Context())
+		response, err := rpc.UCI().DelList(ctx, uciDelListOpts)
+		if err != nil {
+			return err
+		}
+
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	}
+	return err
+}
+
 func NewDeleteCommand() *cobra.Command {
 	o := DeleteOptions{}
 	structType := reflect.TypeOf(o)
@@ -263,6 +387,7 @@ type DeleteOptions struct {
 	Section string
 	Type    string
 	Option  string
+	Options []string
 }
 
 func (o *DeleteOptions) BindFlags(c *cobra.Command) {
@@ -270,7 +395,9 @@ func (o *DeleteOptions) BindFlags(c *cobra.Command) {
 	c.Flags().StringVarP(&o.Section, "section", "s", "", "The section of the config.")
 	c.Flags().StringVarP(&o.Type, "type", "t", "", "The type of the config section.")
 	c.Flags().StringVarP(&o.Option, "option", "o", "", "A single option within a config section.")
+	c.Flags().StringSliceVarP(&o.Options, "options", "", nil, "Several options within a config section.")
 	c.MarkFlagRequired("config")
+	c.MarkFlagsMutuallyExclusive("option", "options")
 }
 
 func (o *DeleteOptions) Run(c *cobra.Command) (err error) {
@@ -280,6 +407,7 @@ func (o *DeleteOptions) Run(c *cobra.Command) (err error) {
 			Section: o.Section,
 			Type:    o.Type,
 			Option:  o.Option,
+			Options: o.Options,
 		}
 		ctx := c.Context()
 		rpc := client.GetFromContext(c.Context())
@@ -350,6 +478,227 @@ func (o *GetOptions) Run(c *cobra.Command) (err error) {
 	return err
 }
 
+func NewImportCommand() *cobra.Command {
+	o := ImportOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "import",
+		Short: "Make a config equal to a UCI file.",
+		Long: "Parse a file in the native UCI format of /etc/config, stage the changes which make the router's " +
+			"config equal to it and print them. The config is named by the file's package statement or its " +
+			"file name unless --config is given.",
+		Args: cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type ImportOptions struct {
+	File    string
+	Config  string
+	DryRun  bool
+	Apply   bool
+	Timeout int
+}
+
+func (o *ImportOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "The UCI file to import.")
+	c.Flags().StringVarP(&o.Config, "config", "c", "", "Name of the config to import into.")
+	c.Flags().BoolVarP(&o.DryRun, "dry-run", "", false, "Only print the changes, do not stage them.")
+	c.Flags().BoolVarP(&o.Apply, "apply", "", false, "Apply the changes with rollback instead of only staging them.")
+	c.Flags().IntVarP(&o.Timeout, "timeout", "", 30, "Seconds until the changes are rolled back if the router becomes unreachable.")
+	c.MarkFlagRequired("file")
+	c.MarkFlagsMutuallyExclusive("dry-run", "apply")
+}
+
+func (o *ImportOptions) Run(c *cobra.Command) error {
+	f, err := os.Open(o.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	file, err := uciformat.Parse(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", o.File, err)
+	}
+
+	config := o.Config
+	if config == "" {
+		config = file.Package
+	}
+	if config == "" {
+		config = strings.TrimSuffix(filepath.Base(o.File), filepath.Ext(o.File))
+	}
+	if err = checkConfig(config); err != nil {
+		return err
+	}
+	sections, err := file.ConfigSections(config)
+	if err != nil {
+		return fmt.Errorf("%s: %w", o.File, err)
+	}
+
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	// pruned so that sections and options which are not in the file are deleted
+	plan, err := client.Plan(ctx, rpc, client.DesiredConfig{Config: config, Sections: sections, Prune: true})
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		fmt.Println("no changes")
+		return nil
+	}
+	fmt.Print(plan)
+
+	switch {
+	case o.DryRun:
+		return nil
+	case o.Apply:
+		return plan.Apply(ctx, rpc, client.PlanApplyOptions{Timeout: o.Timeout, Check: client.CheckReachable(rpc)})
+	default:
+		return plan.Script.Run(ctx, rpc)
+	}
+}
+
+func NewOrderCommand() *cobra.Command {
+	o := OrderOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "order",
+		Short: "Reorder config sections.",
+		Long: "Reorder config sections, either by listing them in the new order with --sections or by " +
+			"moving a single --section before or after another one.",
+		Args: cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type OrderOptions struct {
+	Config   string
+	Sections []string
+	Section  string
+	Before   string
+	After    string
+}
+
+func (o *OrderOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.Config, "config", "c", "", "Which config to query.")
+	c.Flags().StringSliceVarP(&o.Sections, "sections", "", nil, "Sections in their new order, unlisted sections follow them.")
+	c.Flags().StringVarP(&o.Section, "section", "s", "", "A single section to move.")
+	c.Flags().StringVarP(&o.Before, "before", "", "", "Move the section right before this one.")
+	c.Flags().StringVarP(&o.After, "after", "", "", "Move the section right after this one.")
+	c.MarkFlagRequired("config")
+	c.MarkFlagsOneRequired("sections", "section")
+	c.MarkFlagsMutuallyExclusive("sections", "section")
+	c.MarkFlagsMutuallyExclusive("before", "after")
+}
+
+func (o *OrderOptions) Run(c *cobra.Command) (err error) {
+	if err = checkConfig(o.Config); err == nil {
+		ctx := c.Context()
+		rpc := client.GetFromContext(c.Context())
+
+		if o.Section != "" {
+			switch {
+			case o.Before != "":
+				return client.MoveSection(ctx, rpc, o.Config, o.Section, client.MoveBefore, o.Before)
+			case o.After != "":
+				return client.MoveSection(ctx, rpc, o.Config, o.Section, client.MoveAfter, o.After)
+			default:
+				return fmt.Errorf("--section needs either --before or --after")
+			}
+		}
+
+		uciOrderOpts := client.UCIOrderOptions{
+			Config:   o.Config,
+			Sections: o.Sections,
+		}
+		response, err := rpc.UCI().Order(ctx, uciOrderOpts)
+		if err != nil {
+			return err
+		}
+		output, _ := json.MarshalIndent(response, "", "  ")
+		fmt.Println(string(output))
+	}
+	return err
+}
+
+func NewReconcileCommand() *cobra.Command {
+	o := ReconcileOptions{}
+	structType := reflect.TypeOf(o)
+	numOptions := structType.NumField()
+	c := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Converge configs to a desired state.",
+		Long: "Compare the configs described in a YAML file with the router, print the changes needed to " +
+			"converge them and apply them with rollback.",
+		Args: cobra.MaximumNArgs(numOptions),
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.Run(c)
+		},
+	}
+	o.BindFlags(c)
+
+	return c
+}
+
+type ReconcileOptions struct {
+	File    string
+	Plan    bool
+	Timeout int
+}
+
+func (o *ReconcileOptions) BindFlags(c *cobra.Command) {
+	c.Flags().StringVarP(&o.File, "file", "f", "", "YAML file with the desired configs.")
+	c.Flags().BoolVarP(&o.Plan, "plan", "", false, "Only print the changes, do not apply them.")
+	c.Flags().IntVarP(&o.Timeout, "timeout", "", 30, "Seconds until the changes are rolled back if the router becomes unreachable.")
+	c.MarkFlagRequired("file")
+}
+
+func (o *ReconcileOptions) Run(c *cobra.Command) (err error) {
+	data, err := os.ReadFile(o.File)
+	if err != nil {
+		return err
+	}
+	desired, err := client.ParseDesiredConfigs(data)
+	if err != nil {
+		return err
+	}
+	for _, d := range desired {
+		if err = checkConfig(d.Config); err != nil {
+			return err
+		}
+	}
+
+	ctx := c.Context()
+	rpc := client.GetFromContext(c.Context())
+	plan, err := client.Plan(ctx, rpc, desired...)
+	if err != nil {
+		return err
+	}
+	if plan.Empty() {
+		fmt.Println("no changes")
+		return nil
+	}
+	fmt.Print(plan)
+	if o.Plan {
+		return nil
+	}
+
+	return plan.Apply(ctx, rpc, client.PlanApplyOptions{Timeout: o.Timeout, Check: client.CheckReachable(rpc)})
+}
+
 func NewRevertCommand() *cobra.Command {
 	o := RevertOptions{}
 	structType := reflect.TypeOf(o)
@@ -430,78 +779,14 @@ func (o *SetOptions) BindFlags(c *cobra.Command) {
 
 func (o *SetOptions) Run(c *cobra.Command) (err error) {
 	if err = checkConfig(o.Config); err == nil {
-		uciSetOpts := client.UCISetOptions{}
-		switch o.Type {
-		case string(dhcp.Boot):
-			uciSetOpts = unmarshalCLIValues[dhcp.BootSectionOptions](o)
-		case string(dhcp.CircuitID):
-			uciSetOpts = unmarshalCLIValues[dhcp.CircuitIDSectionOptions](o)
-		case string(dhcp.DHCP):
-			uciSetOpts = unmarshalCLIValues[dhcp.DHCPSectionOptions](o)
-		case string(dhcp.Dnsmasq):
-			uciSetOpts = unmarshalCLIValues[dhcp.DnsmasqSectionOptions](o)
-		case string(dhcp.Host):
-			uciSetOpts = unmarshalCLIValues[dhcp.HostSectionOptions](o)
-		case string(dhcp.HostRecord):
-			uciSetOpts = unmarshalCLIValues[dhcp.HostRecordSectionOptions](o)
-		case string(dhcp.MAC):
-			uciSetOpts = unmarshalCLIValues[dhcp.MACSectionOptions](o)
-		case string(dhcp.Odhcpd):
-			uciSetOpts = unmarshalCLIValues[dhcp.OdhcpdSectionOptions](o)
-		case string(dhcp.Relay):
-			uciSetOpts = unmarshalCLIValues[dhcp.RelaySectionOptions](o)
-		case string(dhcp.RemoteID):
-			uciSetOpts = unmarshalCLIValues[dhcp.RemoteIDSectionOptions](o)
-		case string(dhcp.SubscrID):
-			uciSetOpts = unmarshalCLIValues[dhcp.SubscrIDSectionOptions](o)
-		case string(dhcp.Tag):
-			uciSetOpts = unmarshalCLIValues[dhcp.TagSectionOptions](o)
-		case string(dhcp.UserClass):
-			uciSetOpts = unmarshalCLIValues[dhcp.UserClassSectionOptions](o)
-		case string(dhcp.VendorClass):
-			uciSetOpts = unmarshalCLIValues[dhcp.VendorClassSectionOptions](o)
-		case string(dropbear.Dropbear):
-			uciSetOpts = unmarshalCLIValues[dropbear.DropbearSectionOptions](o)
-		case string(firewall.Defaults):
-			uciSetOpts = unmarshalCLIValues[firewall.DefaultsSectionOptions](o)
-		case string(firewall.Forwarding):
-			uciSetOpts = unmarshalCLIValues[firewall.ForwardingSectionOptions](o)
-		case string(firewall.IPSet):
-			uciSetOpts = unmarshalCLIValues[firewall.IPSetSectionOptions](o)
-		case string(firewall.Include):
-			uciSetOpts = unmarshalCLIValues[firewall.IncludeSectionOptions](o)
-		case string(firewall.Redirect):
-			uciSetOpts = unmarshalCLIValues[firewall.RedirectSectionOptions](o)
-		case string(firewall.Rule):
-			uciSetOpts = unmarshalCLIValues[firewall.RuleSectionOptions](o)
-		case string(firewall.Zone):
-			uciSetOpts = unmarshalCLIValues[firewall.ZoneSectionOptions](o)
-		case string(network.BridgeVLAN):
-			uciSetOpts = unmarshalCLIValues[network.BridgeVLANSectionOptions](o)
-		case string(network.Device):
-			uciSetOpts = unmarshalCLIValues[network.DeviceSectionOptions](o)
-		case string(network.Globals):
-			uciSetOpts = unmarshalCLIValues[network.GlobalsSectionOptions](o)
-		case string(network.Interface):
-			uciSetOpts = unmarshalCLIValues[network.InterfaceSectionOptions](o)
-		case string(network.Switch):
-			uciSetOpts = unmarshalCLIValues[network.SwitchSectionOptions](o)
-		case string(network.SwitchPort):
-			uciSetOpts = unmarshalCLIValues[network.SwitchPortSectionOptions](o)
-		case string(network.SwitchVLAN):
-			uciSetOpts = unmarshalCLIValues[network.SwitchVLANSectionOptions](o)
-		case string(system.System):
-			uciSetOpts = unmarshalCLIValues[system.SystemSectionOptions](o)
-		case string(system.Timeserver):
-			uciSetOpts = unmarshalCLIValues[system.TimeserverSectionOptions](o)
-		case string(uhttpd.Cert):
-			uciSetOpts = unmarshalCLIValues[uhttpd.CertSectionOptions](o)
-		case string(uhttpd.UHTTPd):
-			uciSetOpts = unmarshalCLIValues[uhttpd.UHTTPdSectionOptions](o)
-		case string(wireless.WifiDevice):
-			uciSetOpts = unmarshalCLIValues[wireless.WifiDeviceSectionOptions](o)
-		case string(wireless.WifiIface):
-			uciSetOpts = unmarshalCLIValues[wireless.WifiIfaceSectionOptions](o)
+		values, err := unmarshalCLIOptions(o.Config, o.Type, o.Values)
+		if err != nil {
+			return err
+		}
+		uciSetOpts := client.UCISetOptions{
+			Config:  o.Config,
+			Section: o.Section,
+			Values:  values,
 		}
 
 		ctx := c.Context()
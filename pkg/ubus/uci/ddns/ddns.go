@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ddns
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "ddns"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	DDNS    = "ddns"
+	Service = "service"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{DDNS, Service}
+
+	uci.RegisterSection[DDNSSection, DDNSSectionOptions](Config, DDNS)
+	uci.RegisterSection[ServiceSection, ServiceSectionOptions](Config, Service)
+}
+
+// the "global" section, settings shared by all services
+type DDNSSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	DDNSSectionOptions       `json:",inline"`
+}
+
+func (in *DDNSSection) DeepCopyInto(out *DDNSSection) {
+	*out = *in
+}
+
+type DDNSSectionOptions struct {
+	// Format of the dates in the log, see date(1).
+	DateFormat uci.Optional[string] `json:"ddns_dateformat,omitzero"`
+	// Directory to write the log files to.
+	LogDir uci.Optional[string] `json:"ddns_logdir,omitzero"`
+	// Number of lines kept in each log file.
+	LogLines uci.Optional[uci.Int] `json:"ddns_loglines,omitzero"`
+	// Directory to keep runtime data in.
+	RunDir uci.Optional[string] `json:"ddns_rundir,omitzero"`
+	// Whether to allow updates with private IP addresses.
+	UpdPrivateIP uci.Optional[uci.Bool] `json:"upd_privateip,omitzero"`
+	// Whether to use curl instead of wget.
+	UseCurl uci.Optional[uci.Bool] `json:"use_curl,omitzero"`
+}
+
+func (DDNSSectionOptions) IsConfigSectionOptions() {}
+
+// a single hostname kept up to date with a DDNS provider
+type ServiceSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	ServiceSectionOptions    `json:",inline"`
+}
+
+func (in *ServiceSection) DeepCopyInto(out *ServiceSection) {
+	*out = *in
+}
+
+type ServiceSectionOptions struct {
+	// Network to send the updates through.
+	BindNetwork uci.Optional[string] `json:"bind_network,omitzero"`
+	// Path of the CA certificate(s) to verify the provider with, or "IGNORE".
+	CACert uci.Optional[string] `json:"cacert,omitzero"`
+	// Interval between checks whether the IP address changed.
+	CheckInterval uci.Optional[uci.Int] `json:"check_interval,omitzero"`
+	// Unit of check_interval: "seconds", "minutes", "hours" or "days".
+	CheckUnit uci.Optional[string] `json:"check_unit,omitzero"`
+	// DNS server to query for the registered IP address.
+	DNSServer uci.Optional[string] `json:"dns_server,omitzero"`
+	// The domain to update, for some providers including the username or token.
+	Domain uci.Optional[string] `json:"domain,omitzero"`
+	// Whether the service is active.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Whether to use TCP for DNS queries.
+	ForceDNSTCP uci.Optional[uci.Bool] `json:"force_dnstcp,omitzero"`
+	// Interval after which an update is sent even if the IP address did not change.
+	ForceInterval uci.Optional[uci.Int] `json:"force_interval,omitzero"`
+	// Whether to only use the IP version of use_ipv6 for all connections.
+	ForceIPVersion uci.Optional[uci.Bool] `json:"force_ipversion,omitzero"`
+	// Unit of force_interval: "minutes", "hours" or "days".
+	ForceUnit uci.Optional[string] `json:"force_unit,omitzero"`
+	// Network interface whose events trigger updates.
+	Interface uci.Optional[string] `json:"interface,omitzero"`
+	// Device to read the IP address from, with ip_source "interface".
+	IPInterface uci.Optional[string] `json:"ip_interface,omitzero"`
+	// Network to read the IP address from, with ip_source "network".
+	IPNetwork uci.Optional[string] `json:"ip_network,omitzero"`
+	// Script which prints the IP address, with ip_source "script".
+	IPScript uci.Optional[string] `json:"ip_script,omitzero"`
+	// Where the IP address is read from: "network", "web", "interface" or "script".
+	IPSource uci.Optional[string] `json:"ip_source,omitzero"`
+	// URL which returns the IP address, with ip_source "web".
+	IPURL uci.Optional[string] `json:"ip_url,omitzero"`
+	// The FQDN to look up to compare the registered IP address against.
+	LookupHost uci.Optional[string] `json:"lookup_host,omitzero"`
+	// Additional parameter required by some providers.
+	ParamEnc uci.Optional[string] `json:"param_enc,omitzero"`
+	// Optional parameter required by some providers.
+	ParamOpt uci.Optional[string] `json:"param_opt,omitzero"`
+	// Password or token of the provider account.
+	Password uci.Optional[string] `json:"password,omitzero"`
+	// Proxy to send the updates through, "[user:password@]proxy:port".
+	Proxy uci.Optional[string] `json:"proxy,omitzero"`
+	// Interval after which a failed update is retried.
+	RetryInterval uci.Optional[uci.Int] `json:"retry_interval,omitzero"`
+	// Number of retries before giving up, 0 retries forever.
+	RetryMaxCount uci.Optional[uci.Int] `json:"retry_max_count,omitzero"`
+	// Unit of retry_interval: "seconds", "minutes", "hours" or "days".
+	RetryUnit uci.Optional[string] `json:"retry_unit,omitzero"`
+	// Name of the provider as listed in /usr/share/ddns, e.g. "cloudflare.com-v4".
+	ServiceName uci.Optional[string] `json:"service_name,omitzero"`
+	// Script to send custom updates with, instead of service_name.
+	UpdateScript uci.Optional[string] `json:"update_script,omitzero"`
+	// URL to send custom updates to, instead of service_name.
+	UpdateURL uci.Optional[string] `json:"update_url,omitzero"`
+	// Whether to send updates over HTTPS.
+	UseHTTPS uci.Optional[uci.Bool] `json:"use_https,omitzero"`
+	// Whether to update the AAAA record instead of the A record.
+	UseIPv6 uci.Optional[uci.Bool] `json:"use_ipv6,omitzero"`
+	// Whether to write a log file to ddns_logdir.
+	UseLogfile uci.Optional[uci.Bool] `json:"use_logfile,omitzero"`
+	// Minimal level logged to syslog, 0 (off) to 4 (errors only).
+	UseSyslog uci.Optional[uci.Int] `json:"use_syslog,omitzero"`
+	// Username of the provider account.
+	Username uci.Optional[string] `json:"username,omitzero"`
+}
+
+func (ServiceSectionOptions) IsConfigSectionOptions() {}
@@ -27,14 +27,19 @@ const (
 	// these are static values for the uci.StaticSectionOptions.Type field
 	Boot        = "boot"
 	CircuitID   = "circuitid"
+	CNAME       = "cname"
 	DHCP        = "dhcp"
 	Dnsmasq     = "dnsmasq"
+	Domain      = "domain"
 	Host        = "host"
 	HostRecord  = "hostrecord"
+	IPSet       = "ipset"
 	MAC         = "mac"
+	MXHost      = "mxhost"
 	Odhcpd      = "odhcpd"
 	Relay       = "relay"
 	RemoteID    = "remoteid"
+	SrvHost     = "srvhost"
 	SubscrID    = "subscrid"
 	Tag         = "tag"
 	UserClass   = "userclass"
@@ -46,7 +51,27 @@ var (
 )
 
 func init() {
-	Sections = []string{Boot, CircuitID, DHCP, Dnsmasq, Host, HostRecord, MAC, Odhcpd, Relay, RemoteID, SubscrID, Tag, UserClass, VendorClass}
+	Sections = []string{Boot, CircuitID, CNAME, DHCP, Dnsmasq, Domain, Host, HostRecord, IPSet, MAC, MXHost, Odhcpd, Relay, RemoteID, SrvHost, SubscrID, Tag, UserClass, VendorClass}
+
+	uci.RegisterSection[BootSection, BootSectionOptions](Config, Boot)
+	uci.RegisterSection[CircuitIDSection, CircuitIDSectionOptions](Config, CircuitID)
+	uci.RegisterSection[CNAMESection, CNAMESectionOptions](Config, CNAME)
+	uci.RegisterSection[DHCPSection, DHCPSectionOptions](Config, DHCP)
+	uci.RegisterSection[DnsmasqSection, DnsmasqSectionOptions](Config, Dnsmasq)
+	uci.RegisterSection[DomainSection, DomainSectionOptions](Config, Domain)
+	uci.RegisterSection[HostSection, HostSectionOptions](Config, Host)
+	uci.RegisterSection[HostRecordSection, HostRecordSectionOptions](Config, HostRecord)
+	uci.RegisterSection[IPSetSection, IPSetSectionOptions](Config, IPSet)
+	uci.RegisterSection[MACSection, MACSectionOptions](Config, MAC)
+	uci.RegisterSection[MXHostSection, MXHostSectionOptions](Config, MXHost)
+	uci.RegisterSection[OdhcpdSection, OdhcpdSectionOptions](Config, Odhcpd)
+	uci.RegisterSection[RelaySection, RelaySectionOptions](Config, Relay)
+	uci.RegisterSection[RemoteIDSection, RemoteIDSectionOptions](Config, RemoteID)
+	uci.RegisterSection[SrvHostSection, SrvHostSectionOptions](Config, SrvHost)
+	uci.RegisterSection[SubscrIDSection, SubscrIDSectionOptions](Config, SubscrID)
+	uci.RegisterSection[TagSection, TagSectionOptions](Config, Tag)
+	uci.RegisterSection[UserClassSection, UserClassSectionOptions](Config, UserClass)
+	uci.RegisterSection[VendorClassSection, VendorClassSectionOptions](Config, VendorClass)
 }
 
 type BootSection struct {
@@ -61,21 +86,21 @@ func (in *BootSection) DeepCopyInto(out *BootSection) {
 type BootSectionOptions struct {
 	// Additional options to be added for this network-id. If you specify this, you also need to specify
 	// the network-id.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// The filename the host should request from the boot server.
-	Filename *string `json:"filename,omitempty"`
+	Filename uci.Optional[string] `json:"filename,omitzero"`
 	// DHCPption will always be sent even if the client does not ask for it in the parameter request list. This
 	// is sometimes needed, for example when sending options to PXELinux.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 	// Dnsmasq instance to which the boot section is bound. If not specified the section is valid for all
 	// dnsmasq instances.
-	Instance *string `json:"instance,omitempty"`
+	Instance uci.Optional[string] `json:"instance,omitzero"`
 	// The tag (aka network-id) these boot options should apply to. Applies to all clients if left unspecified.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// The IP address of the boot server.
-	ServerAddress *string `json:"serveraddress,omitempty"`
+	ServerAddress uci.Optional[string] `json:"serveraddress,omitzero"`
 	// The hostname of the boot server.
-	ServerName *string `json:"servername,omitempty"`
+	ServerName uci.Optional[string] `json:"servername,omitzero"`
 }
 
 func (BootSectionOptions) IsConfigSectionOptions() {}
@@ -91,17 +116,35 @@ func (in *CircuitIDSection) DeepCopyInto(out *CircuitIDSection) {
 
 type CircuitIDSectionOptions struct {
 	// Matches the circuit ID as sent by the relay agent, as defined in RFC3046.
-	CircuitID *string `json:"circuitid,omitempty"`
+	CircuitID uci.Optional[string] `json:"circuitid,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (CircuitIDSectionOptions) IsConfigSectionOptions() {}
 
+type CNAMESection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	CNAMESectionOptions      `json:",inline"`
+}
+
+func (in *CNAMESection) DeepCopyInto(out *CNAMESection) {
+	*out = *in
+}
+
+type CNAMESectionOptions struct {
+	// The alias to resolve.
+	CNAME uci.Optional[string] `json:"cname,omitzero"`
+	// The name the alias resolves to, must be known to dnsmasq (e.g. from DHCP or /etc/hosts).
+	Target uci.Optional[string] `json:"target,omitzero"`
+}
+
+func (CNAMESectionOptions) IsConfigSectionOptions() {}
+
 type DHCPSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	DHCPSectionOptions       `json:",inline"`
@@ -113,96 +156,96 @@ func (in *DHCPSection) DeepCopyInto(out *DHCPSection) {
 
 type DHCPSectionOptions struct {
 	// Specifies whether DHCPv4 server should be enabled (server) or disabled (disabled).
-	DHCPv4 *string `json:"dhcpv4,omitempty"`
+	DHCPv4 uci.Optional[string] `json:"dhcpv4,omitzero"`
 	// Specifies whether DHCPv6 server should be enabled (server), relayed (relay) or disabled (disabled).
-	DHCPv6 *string `json:"dhcpv6,omitempty"`
+	DHCPv6 uci.Optional[string] `json:"dhcpv6,omitzero"`
 	// The ID dhcp_option here must be with written with an underscore. OpenWrt will translate this to
 	// --dhcp-option, with a hyphen, as ultimately used by dnsmasq. Multiple option values can be given for
 	// this network-id, with a a space between them and the total string between “”. E.g. '26,1470' or
 	// 'option:mtu, 1470' that can assign an MTU per DHCP. Your client must accept MTU by DHCP for this to work.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Exactly the same as dhcp_option (note the underscores), but it will be translated to --dhcp-option-force,
 	// meaning that the DHCP option will be sent regardless on whether the client requested it.
-	DHCPOptionForce *uci.List `json:"dhcp_option_force,omitempty"`
+	DHCPOptionForce uci.Optional[uci.List] `json:"dhcp_option_force,omitzero"`
 	// DNS servers to announce on the network. Only IPv6 addresses are accepted. To configure IPv4 DNS servers,
 	// use DHCPOption.
-	DNS *uci.List `json:"dns,omitempty"`
+	DNS uci.Optional[uci.List] `json:"dns,omitzero"`
 	// Announce the IPv6 address of interface as DNS service if the list of dns option is empty.
-	DNSService *uci.Bool `json:"dns_service,omitempty"`
+	DNSService uci.Optional[uci.Bool] `json:"dns_service,omitzero"`
 	// Dynamically allocate client addresses, if set to 0 only clients present in the ethers files are served.
-	DynamicDHCP *uci.Bool `json:"dynamicdhcp,omitempty"`
+	DynamicDHCP uci.Optional[uci.Bool] `json:"dynamicdhcp,omitzero"`
 	// Forces DHCP serving on the specified interface even if another DHCP server is detected on the same network
 	// segment.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 	// Specifies whether dnsmasq should ignore this pool if set to "1".
-	Ignore *uci.Bool `json:"ignore,omitempty"`
+	Ignore uci.Optional[uci.Bool] `json:"ignore,omitzero"`
 	// Dnsmasq instance to which the dhcp section is bound; if not specified the section is valid for all dnsmasq
 	// instances.
-	Instance *string `json:"instance,omitempty"`
+	Instance uci.Optional[string] `json:"instance,omitzero"`
 	// Specifies the interface associated with this DHCP address pool; must be one of the interfaces defined in
 	// /etc/config/network.
-	Interface *string `json:"interface,omitempty"`
+	Interface uci.Optional[string] `json:"interface,omitzero"`
 	// Specifies the lease time of addresses handed out to clients, for example 12h or 30m.
-	LeaseTime *string `json:"leasetime,omitempty"`
+	LeaseTime uci.Optional[string] `json:"leasetime,omitzero"`
 	// Specifies the size of the address pool (e.g. with start=100, limit=150, maximum address will be .249).
-	Limit *uci.Int `json:"limit,omitempty"`
+	Limit uci.Optional[uci.Int] `json:"limit,omitzero"`
 	// Specifies whether DHCPv6, RA and NDP in relay mode is a master interface or not.
-	Master *uci.Bool `json:"master,omitempty"`
+	Master uci.Optional[uci.Bool] `json:"master,omitzero"`
 	// The dhcp functionality defined in the dhcp section is limited to the interface indicated here through
 	// its network-id. In case omitted the system tries to know the network-id via the interface setting in this
 	// dhcp section, through consultation of /etc/config/network. Some IDs get assigned dynamically, are not provided
 	// by network, but still can be set here.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Specifies whether NDP should be relayed (relay) or disabled (disabled).
-	NDP *string `json:"ndp,omitempty"`
+	NDP uci.Optional[string] `json:"ndp,omitzero"`
 	// Ignore neighbor messages on slave enabled ("1") interfaces.
-	NDProxySlave *uci.Bool `json:"ndproxy_slave,omitempty"`
+	NDProxySlave uci.Optional[uci.Bool] `json:"ndproxy_slave,omitzero"`
 	// Learn routes from NDP.
-	NDProxyRouting *uci.Bool `json:"ndproxy_routing,omitempty"`
+	NDProxyRouting uci.Optional[uci.Bool] `json:"ndproxy_routing,omitzero"`
 	// Specifies whether Router Advertisements should be enabled (server), relayed (relay) or disabled (disabled).
-	RA *string `json:"ra,omitempty"`
+	RA uci.Optional[string] `json:"ra,omitzero"`
 	// Default router lifetime in the RA message will be set if default route is present and a global IPv6 address (0)
 	// or if default route is present but no global IPv6 address (1) or neither of both conditions (2).
-	RADefault uci.Int `json:"ra_default,omitempty"`
+	RADefault uci.Optional[uci.Int] `json:"ra_default,omitzero"`
 	// List of RA flags to be advertised in RA messages:
 	//	managed-config - get address and other information from DHCPv6 server. If this flag is set, other-config flag is redundant.
 	//	other-config - get other configuration from DHCPv6 server (such as DNS servers).
 	//	home-agent - see IETF docs for details.
 	//	none.
 	// OpenWrt since version 21.02 configures managed-config and other-config by default.
-	RAFlags *uci.List `json:"ra_flags,omitempty"`
+	RAFlags uci.Optional[uci.List] `json:"ra_flags,omitzero"`
 	// Advertised current hop limit (0-255).
-	RAHopLimit *uci.Int `json:"ra_hoplimit,omitempty"`
+	RAHopLimit uci.Optional[uci.Int] `json:"ra_hoplimit,omitzero"`
 	// Maximum advertised MTU.
-	RAMTU *uci.Int `json:"ra_mtu,omitempty"`
+	RAMTU uci.Optional[uci.Int] `json:"ra_mtu,omitzero"`
 	// This option is deprecated. Use ra_flags and ra_slaac options instead.
 	// RA management mode : no M-Flag but A-Flag (0), both M and A flags (1), M flag but not A flag (2).
-	RAManagement *uci.Int `json:"ra_management,omitempty"`
+	RAManagement uci.Optional[uci.Int] `json:"ra_management,omitzero"`
 	// Maximum time interval between RAs (in seconds).
-	RAMaxInterval *uci.Int `json:"ra_maxinterval,omitempty"`
+	RAMaxInterval uci.Optional[uci.Int] `json:"ra_maxinterval,omitzero"`
 	// Minimum time interval between RAs (in seconds) .
-	RAMinInterval *uci.Int `json:"ra_mininterval,omitempty"`
+	RAMinInterval uci.Optional[uci.Int] `json:"ra_mininterval,omitzero"`
 	// Announce prefixes as offlink ("1") in RA messages.
-	RAOfflink *uci.Bool `json:"ra_offlink,omitempty"`
+	RAOfflink uci.Optional[uci.Bool] `json:"ra_offlink,omitzero"`
 	// Announce routes with either high (high), medium (medium) or low (low) priority in RAs.
-	RAPreference *string `json:"ra_preference,omitempty"`
+	RAPreference uci.Optional[string] `json:"ra_preference,omitzero"`
 	// Advertised reachable time (in milliseconds) (0-3600000).
-	RAReachableTime *uci.Int `json:"ra_reachabletime,omitempty"`
+	RAReachableTime uci.Optional[uci.Int] `json:"ra_reachabletime,omitzero"`
 	// Advertised NS retransmission time (in milliseconds) (0-60000).
-	RARetransTime *uci.Int `json:"ra_retranstime,omitempty"`
+	RARetransTime uci.Optional[uci.Int] `json:"ra_retranstime,omitzero"`
 	// Announce DNS configuration in RA messages (RFC8106).
-	RADNS *uci.Bool `json:"ra_dns,omitempty"`
+	RADNS uci.Optional[uci.Bool] `json:"ra_dns,omitzero"`
 	// Announce SLAAC for a prefix (that is, set the A flag in RA messages).
-	RASLAAC *uci.Bool `json:"ra_slaac,omitempty"`
+	RASLAAC uci.Optional[uci.Bool] `json:"ra_slaac,omitzero"`
 	// Advertised router lifetime (in seconds).
-	RALifetime *uci.Int `json:"ra_lifetime,omitempty"`
+	RALifetime uci.Optional[uci.Int] `json:"ra_lifetime,omitzero"`
 	// Limit the preferred and valid lifetimes of the prefixes in the RA messages to the configured DHCP leasetime.
-	RAUseLeaseTime *uci.Bool `json:"ra_useleasetime,omitempty"`
+	RAUseLeaseTime uci.Optional[uci.Bool] `json:"ra_useleasetime,omitzero"`
 	// Specifies the offset from the network address of the underlying interface to calculate the minimum address that may be
 	// leased to clients. It may be greater than 255 to span subnets.
-	Start *uci.Int `json:"start,omitempty"`
+	Start uci.Optional[uci.Int] `json:"start,omitzero"`
 	// List of tags that dnsmasq needs to match to use with --dhcp-range.
-	Tag *uci.List `json:"tag,omitempty"`
+	Tag uci.Optional[uci.List] `json:"tag,omitzero"`
 }
 
 func (DHCPSectionOptions) IsConfigSectionOptions() {}
@@ -219,12 +262,12 @@ func (in *DnsmasqSection) DeepCopyInto(out *DnsmasqSection) {
 type DnsmasqSectionOptions struct {
 	uci.StaticSectionOptions `json:",inline"`
 	// List of IP addresses for queried domains. See the dnsmasq man page for syntax details.
-	Address *uci.List `json:"address,omitempty"`
+	Address uci.Optional[uci.List] `json:"address,omitzero"`
 	// Add the local domain as search directive in resolv.conf.
-	AddLocalDomain *uci.Bool `json:"add_local_domain,omitempty"`
+	AddLocalDomain uci.Optional[uci.Bool] `json:"add_local_domain,omitzero"`
 	// Add A, AAAA, and PTR records for this router only on DHCP served LAN.
 	// Enhanced function available since OpenWrt 18.06 with option AddLocalFQDN
-	AddLocalHostname *uci.Bool `json:"add_local_hostname,omitempty"`
+	AddLocalHostname uci.Optional[uci.Bool] `json:"add_local_hostname,omitzero"`
 	// Add A, AAAA, and PTR records for this router only on DHCP served LAN.
 	// 0: Disable.
 	// 1: Hostname on Primary Address.
@@ -232,156 +275,156 @@ type DnsmasqSectionOptions struct {
 	// 3: FDQN on All Addresses.
 	// 4: iface.host.domain on All Addresses.
 	// Available since OpenWrt 18.06
-	AddLocalFQDN *uci.Int `json:"add_local_fqdn,omitempty"`
+	AddLocalFQDN uci.Optional[uci.Int] `json:"add_local_fqdn,omitzero"`
 	// Add the MAC address of the requester to DNS queries which are forwarded upstream; this may be used to do
 	// DNS filtering by the upstream server.
 	// The MAC address can only be added if the requester is on the same subnet as the dnsmasq server. Note that
 	// the mechanism used to achieve this (an EDNS0 option) is not yet standardised, so this should be considered
 	// experimental. Also note that exposing MAC addresses in this way may have security and privacy implications.
 	// The string value must be either "base64" or "text".
-	AddMAC *string `json:"addmac,omitempty"`
+	AddMAC uci.Optional[string] `json:"addmac,omitzero"`
 	// Labels WAN interfaces like add_local_fqdn instead of your ISP assigned default which may be
 	// obscure. WAN is inferred from config dhcp sections with option ignore 1 set, so they do not
 	// need to be named WAN.
 	// Available since OpenWrt 18.06
-	AddWANFQDN *uci.Int `json:"add_wan_fqdn,omitempty"`
+	AddWANFQDN uci.Optional[uci.Int] `json:"add_wan_fqdn,omitzero"`
 	// Additional host files to read for serving DNS responses. Syntax in each file is the same as /etc/hosts.
-	AddnHosts *uci.List `json:"addnhosts,omitempty"`
+	AddnHosts uci.Optional[uci.List] `json:"addnhosts,omitzero"`
 	// Expose additional filesystem paths to the jailed dnsmasq process. This is useful in the case of manually
 	// configured includes in the configuration file or symlinks pointing outside of the exposed paths as used,
 	// for example, by an ad blocker or other name-banning package.
-	AddnMount *uci.List `json:"addnmount,omitempty"`
+	AddnMount uci.Optional[uci.List] `json:"addnmount,omitzero"`
 	// By default, when dnsmasq has more than one upstream server available, it will send queries to just one
 	// server. Setting this parameter forces dnsmasq to send all queries to all available servers. The reply
 	// from the server which answers first will be returned to the original requeser.
-	AllServers *uci.Bool `json:"allservers,omitempty"`
+	AllServers uci.Optional[uci.Bool] `json:"allservers,omitzero"`
 	// Force dnsmasq into authoritative mode. This speeds up DHCP leasing. Used if this is the only server on
 	// the network.
-	Authoritative *uci.Bool `json:"authoritative,omitempty"`
+	Authoritative uci.Optional[uci.Bool] `json:"authoritative,omitzero"`
 	// IP addresses to convert into NXDOMAIN responses (to counteract “helpful” upstream DNS servers that never
 	// return NXDOMAIN).
-	BogusNXDOMAIN *uci.List `json:"bogusnxdomain,omitempty"`
+	BogusNXDOMAIN uci.Optional[uci.List] `json:"bogusnxdomain,omitzero"`
 	// Reject reverse lookups to private IP ranges where no corresponding entry exists in /etc/hosts.
-	BogusPriv *uci.Bool `json:"boguspriv,omitempty"`
+	BogusPriv uci.Optional[uci.Bool] `json:"boguspriv,omitzero"`
 	// When set to 0, use each network interface's DNS address in the local /etc/resolv.conf. Normally, only
 	// the loopback address is used, and all queries go through dnsmasq.
-	CacheLocal *uci.Bool `json:"cachelocal,omitempty"`
+	CacheLocal uci.Optional[uci.Bool] `json:"cachelocal,omitzero"`
 	// Size of dnsmasq query cache.
-	CacheSize *uci.Int `json:"cachesize,omitempty"`
+	CacheSize uci.Optional[uci.Int] `json:"cachesize,omitzero"`
 	// Directory with additional configuration files.
-	ConfDir *string `json:"confdir,omitempty"`
+	ConfDir uci.Optional[string] `json:"confdir,omitzero"`
 	// Enable DBus messaging for dnsmasq.
 	// Standard builds of dnsmasq on OpenWrt do not include DBus support.
-	DBus *uci.Bool `json:"dbus,omitempty"`
+	DBus uci.Optional[uci.Bool] `json:"dbus,omitzero"`
 	// Specifies BOOTP options, in most cases just the file name. You can also use:
 	// “$FILENAME, $TFTP_SERVER_NAME, $TFTP_IP_ADDRESS”.
-	DHCPBoot *string `json:"dhcp_boot,omitempty"`
+	DHCPBoot uci.Optional[string] `json:"dhcp_boot,omitzero"`
 	// Maximum number of concurrent connections.
-	DNSForwardMax *uci.Int `json:"dnsforwardmax,omitempty"`
+	DNSForwardMax uci.Optional[uci.Int] `json:"dnsforwardmax,omitzero"`
 	// Specify an external file with per host DHCP options.
-	DHCPHostsFile *string `json:"dhcphostsfile,omitempty"`
+	DHCPHostsFile uci.Optional[string] `json:"dhcphostsfile,omitzero"`
 	// Maximum number of DHCP leases.
-	DHCPLeaseMax *uci.Int `json:"dhcpleasemax,omitempty"`
+	DHCPLeaseMax uci.Optional[uci.Int] `json:"dhcpleasemax,omitzero"`
 	// Run a custom script upon DHCP lease add / renew / remove actions.
-	DHCPScript *string `json:"dhcpscript,omitempty"`
+	DHCPScript uci.Optional[string] `json:"dhcpscript,omitzero"`
 	// DNS domain handed out to DHCP clients.
-	Domain *string `json:"domain,omitempty"`
+	Domain uci.Optional[string] `json:"domain,omitzero"`
 	// Tells dnsmasq never to forward queries for plain names, without dots or domain parts, to upstream
 	// nameservers. If the name is not known from /etc/hosts or DHCP then a “not found” answer is returned.
-	DomainNeeded *uci.Bool `json:"domainneeded,omitempty"`
+	DomainNeeded uci.Optional[uci.Bool] `json:"domainneeded,omitzero"`
 	// Specify the largest EDNS.0 UDP packet which is supported by the DNS forwarder.
-	EDNSPacketMax *uci.Int `json:"ednspacket_max,omitempty"`
+	EDNSPacketMax uci.Optional[uci.Int] `json:"ednspacket_max,omitzero"`
 	// Enable the builtin TFTP server.
-	EnableTFTP *uci.Bool `json:"enable_tftp,omitempty"`
+	EnableTFTP uci.Optional[uci.Bool] `json:"enable_tftp,omitzero"`
 	// Add the local domain part to names found in /etc/hosts.
-	ExpandHosts *uci.Bool `json:"expandhosts,omitempty"`
+	ExpandHosts uci.Optional[uci.Bool] `json:"expandhosts,omitzero"`
 	// Do not forward requests that cannot be answered by public name servers.
 	// Make sure it is disabled if you need to resolve SRV records or use SIP phones.
-	FilterWin2k *uci.Bool `json:"filterwin2k,omitempty"`
+	FilterWin2k uci.Optional[uci.Bool] `json:"filterwin2k,omitzero"`
 	// Do not resolve unqualifed local hostnames. Needs Domain to be set.
-	FQDN *uci.Bool `json:"fqdn,omitempty"`
+	FQDN uci.Optional[uci.Bool] `json:"fqdn,omitzero"`
 	// List of interfaces to listen on. If unspecified, dnsmasq will listen to all interfaces except those listed
 	// in NotInterface. Note that dnsmasq listens on loopback by default.
-	Interface *uci.List `json:"interface,omitempty"`
+	Interface uci.Optional[uci.List] `json:"interface,omitzero"`
 	// Store DHCP leases in this file.
-	LeaseFile *string `json:"leasefile,omitempty"`
+	LeaseFile uci.Optional[string] `json:"leasefile,omitzero"`
 	// Listen only on the specified IP addresses. If unspecified, listen on IP addresses from each interface.
-	ListenAddress *uci.List `json:"listen_address,omitempty"`
+	ListenAddress uci.Optional[uci.List] `json:"listen_address,omitzero"`
 	// Look up DNS entries for this domain from /etc/hosts. This follows the same syntax as Server entries.
 	// See the dnsmasq man page for more details.
-	Local *string `json:"local,omitempty"`
+	Local uci.Optional[string] `json:"local,omitzero"`
 	// Choose IP address to match the incoming interface if multiple addresses are assigned to a host name in
 	// /etc/hosts. Initially disabled, but still enabled in the config by default.
-	LocaliseQueries *uci.Bool `json:"localise_queries,omitempty"`
+	LocaliseQueries uci.Optional[uci.Bool] `json:"localise_queries,omitzero"`
 	// Accept DNS queries only from hosts whose address is on a local subnet, ie a subnet for which an interface
 	// exists on the server.
-	LocalService *uci.Bool `json:"localservice,omitempty"`
+	LocalService uci.Optional[uci.Bool] `json:"localservice,omitzero"`
 	// Default TTL for locally authoritative answers.
-	LocalTTL *uci.Int `json:"local_ttl,omitempty"`
+	LocalTTL uci.Optional[uci.Int] `json:"local_ttl,omitzero"`
 	// Use dnsmasq as a local system resolver. Depends on the NoResolv and ResolvFile options.
-	LocalUse *uci.Bool `json:"localuse,omitempty"`
+	LocalUse uci.Optional[uci.Bool] `json:"localuse,omitzero"`
 	// Enables extra DHCP logging; logs all the options sent to the DHCP clients and the tags used to determine
 	// them.
-	LogDHCP *uci.Bool `json:"logdhcp,omitempty"`
+	LogDHCP uci.Optional[uci.Bool] `json:"logdhcp,omitzero"`
 	// Set the facility to which dnsmasq will send syslog entries. See the dnsmasq man page for available
 	// facilities.
-	LogFacility *string `json:"logfacility,omitempty"`
+	LogFacility uci.Optional[string] `json:"logfacility,omitzero"`
 	// Log the results of DNS queries, dump cache on SIGUSR1, include requesting IP.
-	LogQueries *uci.Bool `json:"logqueries,omitempty"`
+	LogQueries uci.Optional[uci.Bool] `json:"logqueries,omitzero"`
 	// Set the maximum TTL of DNS answers, even when the TTL in the answer is higher.
-	MaxCacheTTL *uci.Int `json:"max_cache_ttl,omitempty"`
+	MaxCacheTTL uci.Optional[uci.Int] `json:"max_cache_ttl,omitzero"`
 	// Dnsmasq picks random ports as source for outbound queries. When this option is given, the ports used
 	// will always be smaller than or equal to the specified MaxPort value (max valid value 65535). Useful for
 	// systems behind firewalls.
 	// See also MinPort.
-	MaxPort *uci.Int `json:"maxport,omitempty"`
+	MaxPort uci.Optional[uci.Int] `json:"maxport,omitzero"`
 	// Limit the TTL in the DNS answer to this value.
-	MaxTTL *uci.Int `json:"max_ttl,omitempty"`
+	MaxTTL uci.Optional[uci.Int] `json:"max_ttl,omitzero"`
 	// Set the minimum TTL of DNS answers, even when the TTL in the answer is lower.
-	MinCacheTTL *uci.Int `json:"min_cache_ttl,omitempty"`
+	MinCacheTTL uci.Optional[uci.Int] `json:"min_cache_ttl,omitzero"`
 	// Dnsmasq picks random ports as source for outbound queries. When this option is given, the ports used
 	// will always be larger than or equal to the specified MinPort value (min valid value 1024). Useful for
 	// systems behind firewalls.
 	// See also MaxPort.
-	MinPort *uci.Int `json:"minport,omitempty"`
+	MinPort uci.Optional[uci.Int] `json:"minport,omitzero"`
 	// Don't daemonize the dnsmasq process.
-	NoDaemon *uci.Bool `json:"nodaemon,omitempty"`
+	NoDaemon uci.Optional[uci.Bool] `json:"nodaemon,omitzero"`
 	// Don't read DNS names from /etc/hosts.
-	NoHosts *uci.Bool `json:"nohosts,omitempty"`
+	NoHosts uci.Optional[uci.Bool] `json:"nohosts,omitzero"`
 	// Disable caching of negative “no such domain” responses.
-	NoNegCache *uci.Bool `json:"nonegcache,omitempty"`
+	NoNegCache uci.Optional[uci.Bool] `json:"nonegcache,omitzero"`
 	// By default dnsmasq checks if an IPv4 address is in use before allocating it to a host by sending ICMP
 	// echo request (aka ping) to the address in question. This parameter allows to disable this check.
-	NoPing *uci.Bool `json:"noping,omitempty"`
+	NoPing uci.Optional[uci.Bool] `json:"noping,omitzero"`
 	// Don't read upstream servers from /etc/resolv.conf which is linked to resolvfile by default.
-	NoResolv *uci.Bool `json:"noresolv,omitempty"`
+	NoResolv uci.Optional[uci.Bool] `json:"noresolv,omitzero"`
 	// Bind only configured interface addresses, instead of the wildcard address.
-	NonWildcard *uci.Bool `json:"nonwildcard,omitempty"`
+	NonWildcard uci.Optional[uci.Bool] `json:"nonwildcard,omitzero"`
 	// Interfaces dnsmasq should not listen on.
-	NotInterface *uci.List `json:"notinterface,omitempty"`
+	NotInterface uci.Optional[uci.List] `json:"notinterface,omitzero"`
 	// Listening port for DNS queries, disables DNS server functionality if set to 0.
-	Port *uci.Int `json:"port,omitempty"`
+	Port uci.Optional[uci.Int] `json:"port,omitzero"`
 	// Use a fixed port for outbound DNS queries.
-	QueryPort *uci.Int `json:"queryport,omitempty"`
+	QueryPort uci.Optional[uci.Int] `json:"queryport,omitzero"`
 	// Suppress logging of the routine operation of DHCP. Errors and problems will still be logged.
-	QuietDHCP *uci.Bool `json:"quietdhcp,omitempty"`
+	QuietDHCP uci.Optional[uci.Bool] `json:"quietdhcp,omitzero"`
 	// Enable DHCPv4 Rapid Commit (fast address assignment) See RFC 4039.
-	RapidCommit *uci.Bool `json:"rapidcommit,omitempty"`
+	RapidCommit uci.Optional[uci.Bool] `json:"rapidcommit,omitzero"`
 	// Read static lease entries from /etc/ethers, re-read on SIGHUP.
-	ReadEthers *uci.Bool `json:"readethers,omitempty"`
+	ReadEthers uci.Optional[uci.Bool] `json:"readethers,omitzero"`
 	// Enables DNS rebind attack protection by discarding upstream RFC1918 responses.
-	RebindProtection *uci.Bool `json:"rebind_protection,omitempty"`
+	RebindProtection uci.Optional[uci.Bool] `json:"rebind_protection,omitzero"`
 	// Allows upstream 127.0.0.0/8 responses, required for DNS based blacklist services, only takes effect if
 	// rebind protection is enabled.
-	RebindLocalhost *uci.Bool `json:"rebind_localhost,omitempty"`
+	RebindLocalhost uci.Optional[uci.Bool] `json:"rebind_localhost,omitzero"`
 	// List of domains to allow RFC1918 responses for, only takes effect if rebind protection is enabled.
 	// The correct syntax is: `list rebind_domain '/example.com/'`
-	RebindDomain *uci.List `json:"rebind_domain,omitempty"`
+	RebindDomain uci.Optional[uci.List] `json:"rebind_domain,omitzero"`
 	// Specifies an alternative resolv file.
-	ResolvFile *string `json:"resolvfile,omitempty"`
+	ResolvFile uci.Optional[string] `json:"resolvfile,omitzero"`
 	// List of network range with a DNS server to forward reverse DNS requests to. See the dnsmasq man page
 	// for syntax details.
-	RevServer *uci.List `json:"rev_server,omitempty"`
+	RevServer uci.Optional[uci.List] `json:"rev_server,omitzero"`
 	// Dnsmasq is designed to choose IP addresses for DHCP clients using a hash of the client's MAC address.
 	// This normally allows a client's address to remain stable long-term, even if the client sometimes allows
 	// its DHCP lease to expire. In this default mode IP addresses are distributed pseudo-randomly over the
@@ -390,21 +433,39 @@ type DnsmasqSectionOptions struct {
 	// address, and setting this parameter enables this mode. Note that in the sequential mode, clients which
 	// allow a lease to expire are much more likely to move IP address; for this reason it should not be
 	// generally used.
-	SequentialIP *uci.Bool `json:"sequential_ip,omitempty"`
+	SequentialIP uci.Optional[uci.Bool] `json:"sequential_ip,omitzero"`
 	// List of DNS servers to forward requests to. See the dnsmasq man page for syntax details.
-	Server *uci.List `json:"server,omitempty"`
+	Server uci.Optional[uci.List] `json:"server,omitzero"`
 	// Specify upstream servers directly. If one or more optional domains are given, that server is used only
 	// for those domains and they are queried only using the specified server.
 	// Syntax is `server=/*.mydomain.tld/192.168.100.1` or see the dnsmasq man page for details.
-	ServerList *string `json:"serverlist,omitempty"`
+	ServerList uci.Optional[string] `json:"serverlist,omitzero"`
 	// Obey order of DNS servers in /etc/resolv.conf.
-	StrictOrder *uci.Bool `json:"strictorder,omitempty"`
+	StrictOrder uci.Optional[uci.Bool] `json:"strictorder,omitzero"`
 	// Specifies the TFTP root directory.
-	TFTPRoot *string `json:"tftp_root,omitempty"`
+	TFTPRoot uci.Optional[string] `json:"tftp_root,omitzero"`
 }
 
 func (DnsmasqSectionOptions) IsConfigSectionOptions() {}
 
+type DomainSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	DomainSectionOptions     `json:",inline"`
+}
+
+func (in *DomainSection) DeepCopyInto(out *DomainSection) {
+	*out = *in
+}
+
+type DomainSectionOptions struct {
+	// The hostname to resolve.
+	Name uci.Optional[string] `json:"name,omitzero"`
+	// The IP address to resolve the hostname to.
+	IP uci.Optional[string] `json:"ip,omitzero"`
+}
+
+func (DomainSectionOptions) IsConfigSectionOptions() {}
+
 type HostSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	HostSectionOptions       `json:",inline"`
@@ -416,28 +477,28 @@ func (in *HostSection) DeepCopyInto(out *HostSection) {
 
 type HostSectionOptions struct {
 	// Force broadcast DHCP response.
-	Broadcast *uci.Bool `json:"broadcast,omitempty"`
+	Broadcast uci.Optional[uci.Bool] `json:"broadcast,omitzero"`
 	// Add static forward and reverse DNS entries for this host.
-	DNS *uci.Bool `json:"dns,omitempty"`
+	DNS uci.Optional[uci.Bool] `json:"dns,omitzero"`
 	// The DHCPv6-DUID of this host.
-	DUID *string `json:"duid,omitempty"`
+	DUID uci.Optional[string] `json:"duid,omitzero"`
 	// The IPv6 interface identifier (address suffix) as hexadecimal number (max. 16 chars, 64 bits, 8 bytes).
-	HostID *string `json:"hostid,omitempty"`
+	HostID uci.Optional[string] `json:"hostid,omitzero"`
 	// The IP address to be used for this host, or ignore to ignore any DHCP request from this host.
-	IP *string `json:"ip,omitempty"`
+	IP uci.Optional[string] `json:"ip,omitzero"`
 	// Dnsmasq instance to which the host section is bound; if not specified the section is valid for all dnsmasq instances.
-	Instance *string `json:"instance,omitempty"`
+	Instance uci.Optional[string] `json:"instance,omitzero"`
 	// Host-specific lease time, e.g. 2m, 3h, 5d.
-	LeaseTime *string `json:"leasetime,omitempty"`
+	LeaseTime uci.Optional[string] `json:"leasetime,omitzero"`
 	// The hardware address(es) of this host, separated by spaces.
-	MAC *string `json:"mac,omitempty"`
+	MAC uci.Optional[string] `json:"mac,omitzero"`
 	// If specified the section will apply only to requests having all the tags;
 	// incoming interface name is always auto-assigned, other tags can be added by vendorclass/userclass/etc. sections.
-	MatchTag *uci.List `json:"match_tag,omitempty"`
+	MatchTag uci.Optional[uci.List] `json:"match_tag,omitzero"`
 	// Optional hostname to assign.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// Set the given tag for matching hosts.
-	Tag *string `json:"tag,omitempty"`
+	Tag uci.Optional[string] `json:"tag,omitzero"`
 }
 
 func (HostSectionOptions) IsConfigSectionOptions() {}
@@ -453,13 +514,35 @@ func (in *HostRecordSection) DeepCopyInto(out *HostRecordSection) {
 
 type HostRecordSectionOptions struct {
 	// The domain name.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// The IP address to resolve the name to.
-	IP *string `json:"ip,omitempty"`
+	IP uci.Optional[string] `json:"ip,omitzero"`
 }
 
 func (HostRecordSectionOptions) IsConfigSectionOptions() {}
 
+type IPSetSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	IPSetSectionOptions      `json:",inline"`
+}
+
+func (in *IPSetSection) DeepCopyInto(out *IPSetSection) {
+	*out = *in
+}
+
+type IPSetSectionOptions struct {
+	// The domains whose resolved addresses are added to the sets.
+	Domain uci.Optional[uci.List] `json:"domain,omitzero"`
+	// The names of the sets to add resolved addresses to.
+	Name uci.Optional[uci.List] `json:"name,omitzero"`
+	// The nftables table the sets are in, defaults to "fw4".
+	Table uci.Optional[string] `json:"table,omitzero"`
+	// The nftables family of the table, defaults to "inet".
+	TableFamily uci.Optional[string] `json:"table_family,omitzero"`
+}
+
+func (IPSetSectionOptions) IsConfigSectionOptions() {}
+
 type MACSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	MACSectionOptions        `json:",inline"`
@@ -471,17 +554,37 @@ func (in *MACSection) DeepCopyInto(out *MACSection) {
 
 type MACSectionOptions struct {
 	// Hardware address of the client.
-	MAC *string `json:"mac,omitempty"`
+	MAC uci.Optional[string] `json:"mac,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (MACSectionOptions) IsConfigSectionOptions() {}
 
+type MXHostSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	MXHostSectionOptions     `json:",inline"`
+}
+
+func (in *MXHostSection) DeepCopyInto(out *MXHostSection) {
+	*out = *in
+}
+
+type MXHostSectionOptions struct {
+	// The domain the mail exchanger is for.
+	Domain uci.Optional[string] `json:"domain,omitzero"`
+	// Preference of the mail exchanger; lower values are preferred.
+	Pref uci.Optional[uci.Int] `json:"pref,omitzero"`
+	// The hostname of the mail exchanger.
+	Relay uci.Optional[string] `json:"relay,omitzero"`
+}
+
+func (MXHostSectionOptions) IsConfigSectionOptions() {}
+
 type OdhcpdSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	OdhcpdSectionOptions     `json:",inline"`
@@ -493,16 +596,16 @@ func (in *OdhcpdSection) DeepCopyInto(out *OdhcpdSection) {
 
 type OdhcpdSectionOptions struct {
 	// Use odhcpd as the main DHCPv4 service.
-	MainDHCP *uci.Bool `json:"maindhcp,omitempty"`
+	MainDHCP uci.Optional[uci.Bool] `json:"maindhcp,omitzero"`
 	// Location of the lease/hostfile for DHCPv4 and DHCPv6.
-	LeaseFile *string `json:"leasefile,omitempty"`
+	LeaseFile uci.Optional[string] `json:"leasefile,omitzero"`
 	// Location of the lease trigger script.
-	LeaseTrigger *string `json:"leasetrigger,omitempty"`
+	LeaseTrigger uci.Optional[string] `json:"leasetrigger,omitzero"`
 	// Enable DHCPv4 if the 'dhcp' section contains a start option, but no dhcpv4 option set.
-	Legacy *uci.Bool `json:"legacy,omitempty"`
+	Legacy uci.Optional[uci.Bool] `json:"legacy,omitzero"`
 	// Syslog level priority (0-7):
 	// 0=emer, 1=alert, 2=crit, 3=err, 4=warn, 5=notice, 6=info, 7=debug
-	LogLevel *uci.Int `json:"loglevel,omitempty"` // TODO add a uci.UCIInt type and use here
+	LogLevel uci.Optional[uci.Int] `json:"loglevel,omitzero"` // TODO add a uci.UCIInt type and use here
 }
 
 func (OdhcpdSectionOptions) IsConfigSectionOptions() {}
@@ -518,15 +621,15 @@ func (in *RelaySection) DeepCopyInto(out *RelaySection) {
 
 type RelaySectionOptions struct {
 	// A unique name for the section, which must be different to every other section's name.
-	ID *string `json:"id,omitempty"`
+	ID uci.Optional[string] `json:"id,omitzero"`
 	// Logical network interface where the destination DHCP server is located.
-	Interface *string `json:"interface,omitempty"`
+	Interface uci.Optional[string] `json:"interface,omitzero"`
 	// IP address to listen for DHCP requests.
-	LocalAddr *string `json:"local_addr,omitempty"`
+	LocalAddr uci.Optional[string] `json:"local_addr,omitzero"`
 	// IP address of the upstream DHCP server accessible through the network given by the interface option. DHCP
 	// responses picked up on the far subnet will be relayed to this server. This address must be routed correctly
 	// (i.e. you can ping it successfully from the OpenWrt command line).
-	ServerAddr *string `json:"server_addr,omitempty"`
+	ServerAddr uci.Optional[string] `json:"server_addr,omitzero"`
 }
 
 func (RelaySectionOptions) IsConfigSectionOptions() {}
@@ -542,17 +645,41 @@ func (in *RemoteIDSection) DeepCopyInto(out *RemoteIDSection) {
 
 type RemoteIDSectionOptions struct {
 	// Matches the remote ID as sent by the relay agent, as defined in RFC3046.
-	RemoteID *string `json:"remoteid,omitempty"`
+	RemoteID uci.Optional[string] `json:"remoteid,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (RemoteIDSectionOptions) IsConfigSectionOptions() {}
 
+type SrvHostSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	SrvHostSectionOptions    `json:",inline"`
+}
+
+func (in *SrvHostSection) DeepCopyInto(out *SrvHostSection) {
+	*out = *in
+}
+
+type SrvHostSectionOptions struct {
+	// Priority of the target host; lower values are preferred.
+	Class uci.Optional[uci.Int] `json:"class,omitzero"`
+	// Port of the service on the target host.
+	Port uci.Optional[uci.Int] `json:"port,omitzero"`
+	// The name of the SRV record, e.g. "_sip._tcp.example.com".
+	SRV uci.Optional[string] `json:"srv,omitzero"`
+	// The hostname providing the service.
+	Target uci.Optional[string] `json:"target,omitzero"`
+	// Relative weight of records with the same class.
+	Weight uci.Optional[uci.Int] `json:"weight,omitzero"`
+}
+
+func (SrvHostSectionOptions) IsConfigSectionOptions() {}
+
 type SubscrIDSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	SubscrIDSectionOptions   `json:",inline"`
@@ -564,13 +691,13 @@ func (in *SubscrIDSection) DeepCopyInto(out *SubscrIDSection) {
 
 type SubscrIDSectionOptions struct {
 	// Matches the subscriber ID as sent by the relay agent, as defined in RFC3993.
-	SubscrID *string `json:"subscrid,omitempty"`
+	SubscrID uci.Optional[string] `json:"subscrid,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (SubscrIDSectionOptions) IsConfigSectionOptions() {}
@@ -586,9 +713,9 @@ func (in *TagSection) DeepCopyInto(out *TagSection) {
 
 type TagSectionOptions struct {
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (TagSectionOptions) IsConfigSectionOptions() {}
@@ -605,13 +732,13 @@ func (in *UserClassSection) DeepCopyInto(out *UserClassSection) {
 type UserClassSectionOptions struct {
 	// String sent by the client representing the user of the client. dnsmasq performs a substring match on the user
 	// class string using this value.
-	UserClass *string `json:"userclass,omitempty"`
+	UserClass uci.Optional[string] `json:"userclass,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (UserClassSectionOptions) IsConfigSectionOptions() {}
@@ -628,13 +755,13 @@ func (in *VendorClassSection) DeepCopyInto(out *VendorClassSection) {
 type VendorClassSectionOptions struct {
 	// String sent by the client representing the vendor of the client. dnsmasq performs a substring match on the
 	// vendor class string using this value.
-	VendorClass *string `json:"vendorclass,omitempty"`
+	VendorClass uci.Optional[string] `json:"vendorclass,omitzero"`
 	// The tag that matching clients will be assigned.
-	NetworkID *string `json:"networkid,omitempty"`
+	NetworkID uci.Optional[string] `json:"networkid,omitzero"`
 	// Additional options to be added for this tag aka networkid.
-	DHCPOption *uci.List `json:"dhcp_option,omitempty"`
+	DHCPOption uci.Optional[uci.List] `json:"dhcp_option,omitzero"`
 	// Whether to send the additional options from dhcp_option list to the clients that didn't request them.
-	Force *uci.Bool `json:"force,omitempty"`
+	Force uci.Optional[uci.Bool] `json:"force,omitzero"`
 }
 
 func (VendorClassSectionOptions) IsConfigSectionOptions() {}
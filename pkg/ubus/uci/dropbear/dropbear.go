@@ -34,6 +34,8 @@ var (
 
 func init() {
 	Sections = []string{Dropbear}
+
+	uci.RegisterSection[DropbearSection, DropbearSectionOptions](Config, Dropbear)
 }
 
 type DropbearSection struct {
@@ -47,37 +49,37 @@ func (in *DropbearSection) DeepCopyInto(out *DropbearSection) {
 
 type DropbearSectionOptions struct {
 	// Path to a file to display before authentication.
-	BannerFile *string `json:"BannerFile,omitempty"`
+	BannerFile uci.Optional[string] `json:"BannerFile,omitzero"`
 	// Set to 0 to disable password authentication.
-	PasswordAuth *uci.Bool `json:"PasswordAuth,omitempty"`
+	PasswordAuth uci.Optional[uci.Bool] `json:"PasswordAuth,omitzero"`
 	// Port to listen on for SSH connections.
-	Port *uci.Int `json:"Port,omitempty"`
+	Port uci.Optional[uci.Int] `json:"Port,omitzero"`
 	// Set to 0 to disable root password authentication.
-	RootPasswordAuth *uci.Bool `json:"RootPasswordAuth,omitempty"`
+	RootPasswordAuth uci.Optional[uci.Bool] `json:"RootPasswordAuth,omitzero"`
 	// Set to 0 to disable SSH login as root.
-	RootLogin *uci.Bool `json:"RootLogin,omitempty"`
+	RootLogin uci.Optional[uci.Bool] `json:"RootLogin,omitzero"`
 	// Set to 1 to allow remote hosts to connect to forwarded ports.
-	GatewayPorts *uci.Bool `json:"GatewayPorts,omitempty"`
+	GatewayPorts uci.Optional[uci.Bool] `json:"GatewayPorts,omitzero"`
 	// Limit SSH access to a specific interface (e.g. "lan").
-	Interface *string `json:"Interface,omitempty"`
+	Interface uci.Optional[string] `json:"Interface,omitzero"`
 	// Disconnect session after this many seconds of no activity (even with keepalives).
-	IdleTimeout *uci.Int `json:"IdleTimeout,omitempty"`
+	IdleTimeout uci.Optional[uci.Int] `json:"IdleTimeout,omitzero"`
 	// List of key file paths for host keys.
-	KeyFile *uci.List `json:"keyfile,omitempty"`
+	KeyFile uci.Optional[uci.List] `json:"keyfile,omitzero"`
 	// Set to 1 to announce the SSH service via mDNS.
-	MDNS *uci.Int `json:"mdns,omitempty"`
+	MDNS uci.Optional[uci.Int] `json:"mdns,omitzero"`
 	// Maximum allowed failed login attempts before connection closes.
-	MaxAuthTries *uci.Int `json:"MaxAuthTries,omitempty"`
+	MaxAuthTries uci.Optional[uci.Int] `json:"MaxAuthTries,omitzero"`
 	// Set to 0 to disable starting Dropbear on system boot.
-	Enable *uci.Bool `json:"enable,omitempty"`
+	Enable uci.Optional[uci.Bool] `json:"enable,omitzero"`
 	// Per-channel receive window buffer size.
-	RecvWindowSize *uci.Int `json:"RecvWindowSize,omitempty"`
+	RecvWindowSize uci.Optional[uci.Int] `json:"RecvWindowSize,omitzero"`
 	// Deprecated: Path to RSA host key file (use keyfile instead).
-	RSAKeyFile *string `json:"rsakeyfile,omitempty"`
+	RSAKeyFile uci.Optional[string] `json:"rsakeyfile,omitzero"`
 	// Interval (in seconds) for SSH keepalives; 0 disables keepalives.
-	SSHKeepAlive *uci.Int `json:"SSHKeepAlive,omitempty"`
+	SSHKeepAlive uci.Optional[uci.Int] `json:"SSHKeepAlive,omitzero"`
 	// Set to 1 to enable verbose output from the start script.
-	Verbose *uci.Bool `json:"verbose,omitempty"`
+	Verbose uci.Optional[uci.Bool] `json:"verbose,omitzero"`
 }
 
 func (DropbearSectionOptions) IsConfigSectionOptions() {}
@@ -29,6 +29,7 @@ const (
 	Forwarding = "forwarding"
 	IPSet      = "ipset"
 	Include    = "include"
+	NAT        = "nat"
 	Redirect   = "redirect"
 	Rule       = "rule"
 	Zone       = "zone"
@@ -39,7 +40,16 @@ var (
 )
 
 func init() {
-	Sections = []string{Defaults, Forwarding, IPSet, Include, Redirect, Rule, Zone}
+	Sections = []string{Defaults, Forwarding, IPSet, Include, NAT, Redirect, Rule, Zone}
+
+	uci.RegisterSection[DefaultsSection, DefaultsSectionOptions](Config, Defaults)
+	uci.RegisterSection[ForwardingSection, ForwardingSectionOptions](Config, Forwarding)
+	uci.RegisterSection[IncludeSection, IncludeSectionOptions](Config, Include)
+	uci.RegisterSection[IPSetSection, IPSetSectionOptions](Config, IPSet)
+	uci.RegisterSection[NATSection, NATSectionOptions](Config, NAT)
+	uci.RegisterSection[RedirectSection, RedirectSectionOptions](Config, Redirect)
+	uci.RegisterSection[RuleSection, RuleSectionOptions](Config, Rule)
+	uci.RegisterSection[ZoneSection, ZoneSectionOptions](Config, Zone)
 }
 
 // used by RuleSection.ICMPType
@@ -114,49 +124,49 @@ func (in *DefaultsSection) DeepCopyInto(out *DefaultsSection) {
 
 type DefaultsSectionOptions struct {
 	// Accepts redirects. Implemented upstream in Linux Kernel.
-	AcceptRedirects *uci.Bool `json:"accept_redirects,omitempty"`
+	AcceptRedirects uci.Optional[uci.Bool] `json:"accept_redirects,omitzero"`
 	// Implemented upstream in Linux Kernel.
-	AcceptSourceRoute *uci.Bool `json:"accept_source_route,omitempty"`
+	AcceptSourceRoute uci.Optional[uci.Bool] `json:"accept_source_route,omitzero"`
 	// Determines method of packet rejection.
-	AnyRejectCode *int `json:"any_reject_code,omitempty"`
+	AnyRejectCode uci.Optional[uci.Int] `json:"any_reject_code,omitzero"`
 	// Enable Conntrack helpers.
-	AutoHelper *uci.Bool `json:"auth_helper,omitempty"`
+	AutoHelper uci.Optional[uci.Bool] `json:"auto_helper,omitzero"`
 	// (fw4 only, OpenWrt 22.03 and later) Enable automatic nftables includes under /usr/share/nftables.d/
-	AutoIncludes *uci.Bool `json:"auto_includes,omitempty"`
+	AutoIncludes uci.Optional[uci.Bool] `json:"auto_includes,omitzero"`
 	// Enable generation of custom rule chain hooks for user generated rules. User rules would be typically
 	// stored in firewall.user but some packages e.g. BCP38 also make use of these hooks.
-	CustomChains *uci.Bool `json:"custom_chains,omitempty"`
+	CustomChains uci.Optional[uci.Bool] `json:"custom_chains,omitzero"`
 	// Disable IPv6 firewall rules. (not supported by fw4).
-	DisableIPv6 *uci.Bool `json:"disable_ipv6,omitempty"`
+	DisableIPv6 uci.Optional[uci.Bool] `json:"disable_ipv6,omitzero"`
 	// Drop invalid packets (e.g. not matching any active connection).
-	DropInvalid *uci.Bool `json:"drop_invalid"`
+	DropInvalid uci.Optional[uci.Bool] `json:"drop_invalid,omitzero"`
 	// Enable software flow offloading for connections (decrease cpu load / increase routing throughput).
-	FlowOffloading *uci.Bool `json:"flow_offloading,omitempty"`
+	FlowOffloading uci.Optional[uci.Bool] `json:"flow_offloading,omitzero"`
 	// Enable hardware flow offloading for connecions (depends on flow_offloading and hw capability).
-	FlowOffloadingHW *uci.Bool `json:"flow_offloading_hw,omitempty"`
+	FlowOffloadingHW uci.Optional[uci.Bool] `json:"flow_offloading_hw,omitzero"`
 	// Set policy for the FORWARD chain of the filter table.
-	Forward *string `json:"forward,omitempty"`
+	Forward uci.Optional[string] `json:"forward,omitzero"`
 	// Set policy for the INPUT chain of the filter table.
-	Input *string `json:"input,omitempty"`
+	Input uci.Optional[string] `json:"input,omitzero"`
 	// Set policy for the OUTPUT chain of the filter table.
-	Output *string `json:"output,omitempty"`
+	Output uci.Optional[string] `json:"output,omitzero"`
 	// Enable SYN flood protection (obsoleted by synflood_protect setting).
-	SynFlood *uci.Bool `json:"synFlood,omitempty" ubsu:"syn_flood,omitempty"`
+	SynFlood uci.Optional[uci.Bool] `json:"syn_flood,omitzero"`
 	// Enable SYN flood protection.
-	SynFloodProtect *uci.Bool `json:"synflood_protect,omitempty"`
+	SynFloodProtect uci.Optional[uci.Bool] `json:"synflood_protect,omitzero"`
 	// Set rate limit (packets/second) for SYN packets above which the traffic is considered a flood.
-	SynFloodRate *string `json:"synflood_rate,omitempty"`
+	SynFloodRate uci.Optional[string] `json:"synflood_rate,omitzero"`
 	// Set burst limit for SYN packets above which the traffic is considered a flood if it exceeds the allowed rate.
-	SynFloodBurst *string `json:"synflood_burst,omitempty"`
+	SynFloodBurst uci.Optional[string] `json:"synflood_burst,omitzero"`
 	// 0 Disable, 1 Enable, 2 Enable when requested for ingress (but disable for egress) Explicit Congestion
 	// Notification. Affects only traffic originating from the router itself. Implemented upstream in Linux Kernel.
-	TCPECN *int `json:"tcp_ecn,omitempty"`
+	TCPECN uci.Optional[uci.Int] `json:"tcp_ecn,omitzero"`
 	// Enable the use of SYN cookies.
-	TCPSynCookies *uci.Bool `json:"tcp_syncookies,omitempty"`
+	TCPSynCookies uci.Optional[uci.Bool] `json:"tcp_syncookies,omitzero"`
 	// Determines method of packet rejection.
-	TCPRejectCode *int `json:"tcp_reject_code,omitempty"`
+	TCPRejectCode uci.Optional[uci.Int] `json:"tcp_reject_code,omitzero"`
 	// Enable TCP window scaling.
-	TCPWindowScaling *uci.Bool `json:"tcp_window_scaling,omitempty"`
+	TCPWindowScaling uci.Optional[uci.Bool] `json:"tcp_window_scaling,omitzero"`
 }
 
 func (DefaultsSectionOptions) IsConfigSectionOptions() {}
@@ -172,19 +182,19 @@ func (in *ForwardingSection) DeepCopyInto(out *ForwardingSection) {
 
 type ForwardingSectionOptions struct {
 	// Specifies the traffic destination zone. Refers to one of the defined zone names.
-	Dest *string `json:"dest,omitempty"`
+	Dest uci.Optional[string] `json:"dest,omitzero"`
 	// If set to 0, forward is disabled.
-	Enabled *uci.Bool `json:"enabled,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// Specifies the address family (ipv4, ipv6 or any) for which the rules are generated.
-	Family *string `json:"family,omitempty"`
+	Family uci.Optional[string] `json:"family,omitzero"`
 	// If specified, match traffic against the given ipset. The match can be inverted by prefixing the value
 	// with an exclamation mark.
-	IPSet *string `json:"ipset,omitempty"`
+	IPSet uci.Optional[string] `json:"ipset,omitzero"`
 	// Unique forwarding name.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// Specifies the traffic source zone. Refers to one of the defined zone names. For typical port forwards this
 	// usually is 'wan'.
-	Src *string `json:"src,omitempty"`
+	Src uci.Optional[string] `json:"src,omitzero"`
 }
 
 func (ForwardingSectionOptions) IsConfigSectionOptions() {}
@@ -202,46 +212,46 @@ func (in *IPSetSection) DeepCopyInto(out *IPSetSection) {
 type IPSetSectionOptions struct {
 	// The IP address, CIDR, or MAC. Each list entry is a single CIDR, or IP etc when not using ranges or masks etc
 	// above.
-	Entry *uci.List `json:"entry,omitempty"`
+	Entry uci.Optional[uci.List] `json:"entry,omitzero"`
 	// Allows to disable the declaration of the ipset without the need to delete the section.
-	Enabled *uci.Bool `json:"enabled,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// If the external option is set to a name, the firewall will simply reference an already existing ipset pointed
 	// to by the name. If the external option is unset, the firewall will create the ipset on start and destroy it on
 	// stop.
-	External *string `json:"external,omitempty"`
+	External uci.Optional[string] `json:"external,omitzero"`
 	// Specifies the address family (ipv4 or ipv6) for which the IP set is created.
 	// Only applicable to storage types hash and list, the bitmap type implies ipv4.
-	Family *string `json:"family,omitempty"`
+	Family uci.Optional[string] `json:"family,omitzero"`
 	// Specifies the initial hash size of the set, only applicable to the hash storage type.
-	HashSize *uci.Int `json:"hashsize,omitempty"`
+	HashSize uci.Optional[uci.Int] `json:"hashsize,omitzero"`
 	// Specifies the IP range to cover, see ipset(8). Only applicable to the hash storage type.
-	IPRange *string `json:"iprange,omitempty"`
+	IPRange uci.Optional[string] `json:"iprange,omitzero"`
 	// A path URL on the openwrt filesystem to a file containing a list of CIDRs.
-	LoadFile *string `json:"loadfile,omitempty"`
+	LoadFile uci.Optional[string] `json:"loadfile,omitzero"`
 	// Specifies the matched data types (ip, port, mac, net or set) and their direction (src or dest).
 	// The direction is joined with the datatype by an underscore to form a tuple, e.g. src_port to match source ports
 	// or dest_net to match destination CIDR ranges. When using ipsets matching on multiple elements,
 	// e.g. hash:ip,port, specify the packet fields to match on in quotes or comma-separated
 	// (i.e. “match dest_ip dest_port”).
-	Match *uci.List `json:"match,omitempty"`
+	Match uci.Optional[uci.List] `json:"match,omitzero"`
 	// Limits the number of items that can be added to the set, only applicable to the hash and list storage types.
-	MaxElem *uci.Int `json:"maxelem,omitempty"`
+	MaxElem uci.Optional[uci.Int] `json:"maxelem,omitzero"`
 	// Specifies the firewall internal name of the ipset which is used to reference the set in rules or redirects.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// If specified, network addresses will be stored in the set instead of IP host addresses.
 	// Value must be between 1 and 32, see ipset(8).
 	// Only applicable to the bitmap storage type with match ip or the hash storage type with match ip.
-	Netmask *uci.Int `json:"netmask,omitempty"`
+	Netmask uci.Optional[uci.Int] `json:"netmask,omitzero"`
 	// Specifies the port range to cover, see ipset(8). Only applicable to the hash storage type.
-	PortRange *string `json:"portrange,omitempty"`
+	PortRange uci.Optional[string] `json:"portrange,omitzero"`
 	// Specifies the storage method (bitmap, hash or list) used by the ipset, the default varies depending on the used
 	// datatypes (see match option). In most cases the storage method can be automatically inferred from the datatype
 	// combination but in some cases multiple choices are possible (e.g. bitmap:ip vs. hash:ip).
 	// This is only required by fw3 and must be removed from the fw4 configuration.
-	Storage *string `json:"storage,omitempty"`
+	Storage uci.Optional[string] `json:"storage,omitzero"`
 	// Specifies the default timeout for entries added to the set. A value of 0 means enabling the timeout capability
 	// flag on a set, but do not put the timeout to entries.
-	Timeout *uci.Int `json:"timeout,omitempty"`
+	Timeout uci.Optional[uci.Int] `json:"timeout,omitzero"`
 }
 
 func (IPSetSectionOptions) IsConfigSectionOptions() {}
@@ -257,20 +267,85 @@ func (in *IncludeSection) DeepCopyInto(out *IncludeSection) {
 
 type IncludeSectionOptions struct {
 	// Specifies the chain in which the rules will be inserted.
-	Chain *string `json:"name,omitempty"`
+	Chain uci.Optional[string] `json:"chain,omitzero"`
 	// Allows to disable the corresponding include without having to delete the section.
-	Enabled *uci.Bool `json:"dest,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// Specifies the filename to include.
-	Path *string `json:"family,omitempty"`
+	Path uci.Optional[string] `json:"path,omitzero"`
 	// Specifies the position at which the rules will be inserted (see below for allowed values).
-	Position *string `json:"ipset,omitempty"`
+	Position uci.Optional[string] `json:"position,omitzero"`
 	// Specifies the type of the include, either script for compatibility with fw3 (shell script, see below)
 	// or nftables for nftables snippets.
-	Type *string `json:"enabled,omitempty"`
+	Type uci.Optional[string] `json:"type,omitzero"`
 }
 
 func (IncludeSectionOptions) IsConfigSectionOptions() {}
 
+type NATSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	NATSectionOptions        `json:",inline"`
+}
+
+func (in *NATSection) DeepCopyInto(out *NATSection) {
+	*out = *in
+}
+
+// (fw4 only, OpenWrt 22.03 and later) source NAT rules which do not depend on a zone's masq option
+type NATSectionOptions struct {
+	// Match outgoing traffic directed to the specified destination IP address, CIDR notations can be used.
+	DestIP uci.Optional[string] `json:"dest_ip,omitzero"`
+	// Match outgoing traffic directed at the given destination port or port range, if relevant proto is specified.
+	DestPort uci.Optional[string] `json:"dest_port,omitzero"`
+	// Match traffic leaving through the given network device.
+	Device uci.Optional[string] `json:"device,omitzero"`
+	// Enable or disable the NAT rule.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Specifies the address family (ipv4 or ipv6) for which the rule is generated.
+	Family uci.Optional[string] `json:"family,omitzero"`
+	// If specified, match traffic against the given ipset. The match can be inverted by prefixing the value with an
+	// exclamation mark.
+	IPSet uci.Optional[string] `json:"ipset,omitzero"`
+	// Maximum average matching rate; specified as a number, with an optional /second, /minute, /hour or /day suffix.
+	Limit uci.Optional[string] `json:"limit,omitzero"`
+	// Maximum initial number of packets to match, allowing a short-term average above limit.
+	LimitBurst uci.Optional[uci.Int] `json:"limit_burst,omitzero"`
+	// If specified, match traffic against the given firewall mark. The match can be inverted by prefixing the value
+	// with an exclamation mark.
+	Mark uci.Optional[string] `json:"mark,omitzero"`
+	// If specified, only match traffic during the given days of the month.
+	Monthdays uci.Optional[string] `json:"monthdays,omitzero"`
+	// Name of the NAT rule.
+	Name uci.Optional[string] `json:"name,omitzero"`
+	// Match traffic using the given protocol, see RuleSectionOptions.Proto.
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// Rewrite the source address of matched traffic to the given address. Required for target SNAT.
+	SNATIP uci.Optional[string] `json:"snat_ip,omitzero"`
+	// Rewrite the source port of matched traffic to the given port or port range.
+	SNATPort uci.Optional[string] `json:"snat_port,omitzero"`
+	// Specifies the traffic source zone. Refers to one of the defined zone names, or * for any zone.
+	Src uci.Optional[string] `json:"src,omitzero"`
+	// Match traffic from the specified source IP address, CIDR notations can be used.
+	SrcIP uci.Optional[string] `json:"src_ip,omitzero"`
+	// Match traffic from the specified source port or port range, if relevant proto is specified.
+	SrcPort uci.Optional[string] `json:"src_port,omitzero"`
+	// If specifed, only match traffic after the given date (inclusive).
+	StartDate uci.Optional[string] `json:"start_date,omitzero"`
+	// If specified, only match traffic after the given time of day (inclusive).
+	StartTime uci.Optional[string] `json:"start_time,omitzero"`
+	// If specified, only match traffic before the given date (inclusive).
+	StopDate uci.Optional[string] `json:"stop_date,omitzero"`
+	// If specified, only match traffic before the given time of day (inclusive).
+	StopTime uci.Optional[string] `json:"stop_time,omitzero"`
+	// NAT action (ACCEPT, SNAT, MASQUERADE) for matched traffic.
+	Target uci.Optional[string] `json:"target,omitzero"`
+	// Treat all given time values as UTC time instead of local time.
+	UTCTime uci.Optional[uci.Bool] `json:"utc_time,omitzero"`
+	// If specified, only match traffic during the given week days.
+	Weekdays uci.Optional[string] `json:"weekdays,omitzero"`
+}
+
+func (NATSectionOptions) IsConfigSectionOptions() {}
+
 type RedirectSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	RedirectSectionOptions   `json:",inline"`
@@ -283,80 +358,80 @@ func (in *RedirectSection) DeepCopyInto(out *RedirectSection) {
 type RedirectSectionOptions struct {
 	// Specifies the traffic destination zone. Refers to one of the defined zone names, or * for any zone. If
 	// specified, the rule applies to forwarded traffic; otherwise, it is treated as input rule.
-	Dest *string `json:"dest,omitempty"`
+	Dest uci.Optional[string] `json:"dest,omitzero"`
 	// Match incoming traffic directed to the specified destination IP address, CIDR notations can be used, see
 	// note above. With no dest zone, this is treated as an input rule!
-	DestIP *string `json:"dest_ip,omitempty"`
+	DestIP uci.Optional[string] `json:"dest_ip,omitzero"`
 	// Match incoming traffic directed at the given destination port or port range, if relevant proto is specified.
 	// Multiple ports can be specified like '80 443 465' 1.
-	DestPort *string `json:"dest_port,omitempty"`
+	DestPort uci.Optional[string] `json:"dest_port,omitzero"`
 	// Enable the redirect rule or not.
-	Enabled *uci.Bool `json:"enabled,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// Specifies the address family (ipv4, ipv6 or any) for which the rules are generated. If unspecified, matches
 	// the address family of other options in this section and defaults to ipv4.
-	Family *string `json:"family,omitempty"`
-	Helper *string `json:"helper,omitempty"`
+	Family uci.Optional[string] `json:"family,omitzero"`
+	Helper uci.Optional[string] `json:"helper,omitzero"`
 	// If specified, match traffic against the given ipset. The match can be inverted by prefixing the value with an
 	// exclamation mark. You can specify the direction as 'setname src' or 'setname dest'. The default if neither src
 	// nor dest are added is to assume src.
-	IPSet *string `json:"ipset,omitempty"`
+	IPSet uci.Optional[string] `json:"ipset,omitzero"`
 	// Maximum average matching rate; specified as a number, with an optional /second, /minute, /hour or /day suffix.
 	// Examples: 3/second, 3/sec or 3/s.
-	Limit *string `json:"limit,omitempty"`
+	Limit uci.Optional[string] `json:"limit,omitzero"`
 	// Maximum initial number of packets to match, allowing a short-term average above limit.
-	LimitBurst *uci.Int `json:"limit_burst,omitempty"`
+	LimitBurst uci.Optional[uci.Int] `json:"limit_burst,omitzero"`
 	// If specified, match traffic against the given firewall mark, e.g. 0xFF to match mark 255 or 0x0/0x1 to match
 	// any even mark value. The match can be inverted by prefixing the value with an exclamation mark, e.g. !0x10 to
 	// match all but mark #16.
-	Mark *string `json:"mark,omitempty"`
+	Mark uci.Optional[string] `json:"mark,omitzero"`
 	// If specified, only match traffic during the given days of the month, e.g. 2 5 30 to only match on every 2nd,
 	// 5th and 30rd day of the month. The list can be inverted by prefixing it with an exclamation mark,
 	// e.g. ! 31 to always match but on the 31st of the month.
-	Monthdays *string `json:"monthdays,omitempty"`
+	Monthdays uci.Optional[string] `json:"monthdays,omitzero"`
 	// Name of redirect.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// Match incoming traffic using the given protocol. Can be one (or several when using list syntax) of tcp, udp,
 	// udplite, icmp, esp, ah, sctp, or all or it can be a numeric value, representing one of these protocols or a
 	// different one. A protocol name from /etc/protocols is also allowed. The number 0 is equivalent to all.
-	Proto *string `json:"proto,omitempty"`
+	Proto uci.Optional[string] `json:"proto,omitzero"`
 	// Activate NAT reflection for this redirect - applicable to DNAT targets.
-	Reflection *uci.Bool `json:"reflection,omitempty"`
+	Reflection uci.Optional[uci.Bool] `json:"reflection,omitzero"`
 	// The source address to use for NAT-reflected packets if reflection is 1. This can be internal or external,
 	// specifying which interface’s address to use. Applicable to DNAT targets.
-	ReflectionSrc *string `json:"reflection_src,omitempty"`
+	ReflectionSrc uci.Optional[string] `json:"reflection_src,omitzero"`
 	// List of zones for which reflection should be enabled. Applicable to DNAT targets.
-	ReflectionZone *uci.List `json:"reflection_zone,omitempty"`
+	ReflectionZone uci.Optional[uci.List] `json:"reflection_zone,omitzero"`
 	// Specifies the traffic source zone. Refers to one of the defined zone names. For typical port forwards this
 	// usually is wan.
-	Src *string `json:"src,omitempty"`
+	Src uci.Optional[string] `json:"src,omitzero"`
 	// For DNAT, match incoming traffic directed at the given destination IP address. For SNAT rewrite the source
 	// address to the given address.
-	SrcDIP *string `json:"src_dip,omitempty"`
+	SrcDIP uci.Optional[string] `json:"src_dip,omitzero"`
 	// For DNAT, match incoming traffic directed at the given destination port or port range on this host. For
 	// SNAT rewrite the source ports to the given value.
-	SrcDPort *string `json:"src_dport,omitempty"`
+	SrcDPort uci.Optional[string] `json:"src_dport,omitzero"`
 	// Match incoming traffic from the specified source IP address.
-	SrcIP *string `json:"src_ip,omitempty"`
+	SrcIP uci.Optional[string] `json:"src_ip,omitzero"`
 	// Match incoming traffic from the specified MAC address.
-	SrcMAC *string `json:"src_mac,omitempty"`
+	SrcMAC uci.Optional[string] `json:"src_mac,omitzero"`
 	// Match incoming traffic originating from the given source port or port range on the client host.
-	SrcPort *string `json:"src_port,omitempty"`
+	SrcPort uci.Optional[string] `json:"src_port,omitzero"`
 	// If specifed, only match traffic after the given date (inclusive).
-	StartDate *string `json:"start_date,omitempty"`
+	StartDate uci.Optional[string] `json:"start_date,omitzero"`
 	// If specified, only match traffic after the given time of day (inclusive).
-	StartTime *string `json:"start_time,omitempty"`
+	StartTime uci.Optional[string] `json:"start_time,omitzero"`
 	// If specified, only match traffic before the given date (inclusive).
-	StopDate *string `json:"stop_date,omitempty"`
+	StopDate uci.Optional[string] `json:"stop_date,omitzero"`
 	// If specified, only match traffic before the given time of day (inclusive).
-	StopTime *string `json:"stop_time,omitempty"`
+	StopTime uci.Optional[string] `json:"stop_time,omitzero"`
 	// If specified, only match traffic during the given week days, e.g. sun mon thu fri to only match on Sundays,
 	// Mondays, Thursdays and Fridays. The list can be inverted by prefixing it with an exclamation mark,
 	// e.g. ! sat sun to always match but on Saturdays and Sundays.
-	Weekdays *string `json:"weekdays,omitempty"`
+	Weekdays uci.Optional[string] `json:"weekdays,omitzero"`
 	// Firewall action (ACCEPT, REJECT, DROP, MARK, NOTRACK) for matched traffic.
-	Target *string `json:"target,omitempty"`
+	Target uci.Optional[string] `json:"target,omitzero"`
 	// Treat all given time values as UTC time instead of local time.
-	UTCTime *uci.Bool `json:"utc_time,omitempty"`
+	UTCTime uci.Optional[uci.Bool] `json:"utc_time,omitzero"`
 }
 
 func (RedirectSectionOptions) IsConfigSectionOptions() {}
@@ -373,80 +448,87 @@ func (in *RuleSection) DeepCopyInto(out *RuleSection) {
 type RuleSectionOptions struct {
 	// Specifies the traffic destination zone. Refers to one of the defined zone names, or * for any zone. If
 	// specified, the rule applies to forwarded traffic; otherwise, it is treated as input rule.
-	Dest *string `json:"dest,omitempty"`
+	Dest uci.Optional[string] `json:"dest,omitzero"`
 	// Match incoming traffic directed to the specified destination IP address, CIDR notations can be used, see
 	// note above. With no dest zone, this is treated as an input rule!
-	DestIP *string `json:"dest_ip,omitempty"`
+	DestIP uci.Optional[string] `json:"dest_ip,omitzero"`
 	// Match incoming traffic directed at the given destination port or port range, if relevant proto is specified.
 	// Multiple ports can be specified like '80 443 465' 1.
-	DestPort  *uci.Int `json:"dest_port,omitempty"`
-	Device    *string  `json:"device,omitempty"`
-	Direction *string  `json:"direction,omitempty"`
+	DestPort  uci.Optional[string] `json:"dest_port,omitzero"`
+	Device    uci.Optional[string] `json:"device,omitzero"`
+	Direction uci.Optional[string] `json:"direction,omitzero"`
+	// (fw4 only) Match traffic with the given DSCP classification, either a class name like AF11 or a numeric value.
+	// The match can be inverted by prefixing the value with an exclamation mark.
+	DSCP uci.Optional[string] `json:"dscp,omitzero"`
 	// Enable or disable rule.
-	Enabled *uci.Bool `json:"enabled,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// Specifies the address family (ipv4, ipv6 or any) for which the rules are generated. If unspecified, matches
 	// the address family of other options in this section and defaults to any.
-	Family *string `json:"family,omitempty"`
-	Helper *string `json:"helper,omitempty"`
+	Family uci.Optional[string] `json:"family,omitzero"`
+	Helper uci.Optional[string] `json:"helper,omitzero"`
 	// For protocol icmp select specific ICMP types to match. Values can be either exact ICMP type numbers or type
 	// names (see ICMPTypes var).
-	ICMPType *uci.List `json:"icmp_type,omitempty"`
+	ICMPType uci.Optional[uci.List] `json:"icmp_type,omitzero"`
 	// If specified, match traffic against the given ipset. The match can be inverted by prefixing the value with an
 	// exclamation mark. You can specify the direction as 'setname src' or 'setname dest'. The default if neither src
 	// nor dest are added is to assume src.
-	IPSet *string `json:"ipset,omitempty"`
+	IPSet uci.Optional[string] `json:"ipset,omitzero"`
 	// Maximum average matching rate; specified as a number, with an optional /second, /minute, /hour or /day suffix.
 	// Examples: 3/minute, 3/min or 3/m.
-	Limit *string `json:"limit,omitempty"`
+	Limit uci.Optional[string] `json:"limit,omitzero"`
 	// Maximum initial number of packets to match, allowing a short-term average above limit.
-	LimitBurst *uci.Int `json:"limit_burst,omitempty"`
+	LimitBurst uci.Optional[uci.Int] `json:"limit_burst,omitzero"`
 	// If specified, match traffic against the given firewall mark, e.g. 0xFF to match mark 255 or 0x0/0x1 to match
 	// any even mark value. The match can be inverted by prefixing the value with an exclamation mark, e.g. !0x10 to
 	// match all but mark #16.
-	Mark *string `json:"mark,omitempty"`
+	Mark uci.Optional[string] `json:"mark,omitzero"`
 	// If specified, only match traffic during the given days of the month, e.g. 2 5 30 to only match on every 2nd,
 	// 5th and 30rd day of the month. The list can be inverted by prefixing it with an exclamation mark,
 	// e.g. ! 31 to always match but on the 31st of the month.
-	Monthdays *string `json:"monthdays,omitempty"`
+	Monthdays uci.Optional[string] `json:"monthdays,omitzero"`
 	// Name of rule.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// Match incoming traffic using the given protocol. Can be one (or several when using list syntax) of tcp,
 	// udp, udplite, icmp, esp, ah, sctp, or all or it can be a numeric value, representing one of these protocols
 	// or a different one. A protocol name from /etc/protocols is also allowed. The number 0 is equivalent to all.
-	Proto *string `json:"proto,omitempty"`
+	Proto uci.Optional[string] `json:"proto,omitzero"`
 	// Zeroes out the bits given by mask and ORs value into the packet mark. If mask is omitted, 0xFFFFFFFF is
 	// assumed.
-	SetMark   *string `json:"set_mark,omitempty"`
-	SetHelper *string `json:"set_helper,omitempty"`
+	SetMark uci.Optional[string] `json:"set_mark,omitzero"`
+	// (fw4 only) Rewrite the DSCP classification of matched traffic, either a class name or a numeric value.
+	// Requires target DSCP.
+	SetDSCP uci.Optional[string] `json:"set_dscp,omitzero"`
+	// Assign the given conntrack helper to matched traffic. Requires target HELPER.
+	SetHelper uci.Optional[string] `json:"set_helper,omitzero"`
 	// Zeroes out the bits given by mask and XORs value into the packet mark. If mask is omitted, 0xFFFFFFFF is
 	// assumed.
-	SetXmark *string `json:"set_xmark,omitempty"`
+	SetXmark uci.Optional[string] `json:"set_xmark,omitzero"`
 	// Specifies the traffic source zone. Refers to one of the defined zone names, or * for any zone. If omitted,
 	// the rule applies to output traffic.
-	Src *string `json:"src,omitempty"`
+	Src uci.Optional[string] `json:"src,omitzero"`
 	// Match incoming traffic from the specified source IP address, CIDR notations can be used, see note above.
-	SrcIP *string `json:"src_ip,omitempty"`
+	SrcIP uci.Optional[string] `json:"src_ip,omitzero"`
 	// Match incoming traffic from the specified MAC address.
-	SrcMAC *string `json:"src_mac,omitempty"`
+	SrcMAC uci.Optional[string] `json:"src_mac,omitzero"`
 	// Match incoming traffic from the specified source port or port range, if relevant proto is specified.
 	// Multiple ports can be specified like '80 443 465' 1.
-	SrcPort *string `json:"src_port,omitempty"`
+	SrcPort uci.Optional[string] `json:"src_port,omitzero"`
 	// If specifed, only match traffic after the given date (inclusive).
-	StartDate *string `json:"start_date,omitempty"`
+	StartDate uci.Optional[string] `json:"start_date,omitzero"`
 	// If specified, only match traffic after the given time of day (inclusive).
-	StartTime *string `json:"start_time,omitempty"`
+	StartTime uci.Optional[string] `json:"start_time,omitzero"`
 	// If specified, only match traffic before the given date (inclusive).
-	StopDate *string `json:"stop_date,omitempty"`
+	StopDate uci.Optional[string] `json:"stop_date,omitzero"`
 	// If specified, only match traffic before the given time of day (inclusive).
-	StopTime *string `json:"stop_time,omitempty"`
+	StopTime uci.Optional[string] `json:"stop_time,omitzero"`
 	// Firewall action (ACCEPT, REJECT, DROP, MARK, NOTRACK) for matched traffic.
-	Target *string `json:"target,omitempty"`
+	Target uci.Optional[string] `json:"target,omitzero"`
 	// Treat all given time values as UTC time instead of local time.
-	UTCTime *uci.Bool `json:"utc_time,omitempty"`
+	UTCTime uci.Optional[uci.Bool] `json:"utc_time,omitzero"`
 	// If specified, only match traffic during the given week days, e.g. sun mon thu fri to only match on Sundays,
 	// Mondays, Thursdays and Fridays. The list can be inverted by prefixing it with an exclamation mark,
 	// e.g. ! sat sun to always match but on Saturdays and Sundays.
-	Weekdays *string `json:"weekdays,omitempty"`
+	Weekdays uci.Optional[string] `json:"weekdays,omitzero"`
 }
 
 func (RuleSectionOptions) IsConfigSectionOptions() {}
@@ -462,54 +544,54 @@ func (in *ZoneSection) DeepCopyInto(out *ZoneSection) {
 
 type ZoneSectionOptions struct {
 	// Add CT helpers for zone.
-	AutoHelper *uci.Bool `json:"auto_helper,omitempty"`
+	AutoHelper uci.Optional[uci.Bool] `json:"auto_helper,omitzero"`
 	// Enable generation of custom rule chain hooks for user generated rules. Has no effect if disabled (0) in a
 	// DefaultsSection.
-	CustomChains *uci.Bool `json:"custom_chains,omitempty"`
+	CustomChains uci.Optional[uci.Bool] `json:"custom_chains,omitzero"`
 	// List of L3 network interface names attached to this zone, e.g. tun+ or ppp+ to match any TUN or PPP interface.
 	// This is specifically suitable for undeclared interfaces which lack built-in netifd support such as OpenVPN.
 	// Otherwise network is preferable and device should be avoided.
-	Device *uci.List `json:"device,omitempty"`
+	Device uci.Optional[uci.List] `json:"device,omitzero"`
 	// If set to 0, zone is disabled
-	Enabled *uci.Bool `json:"enabled,omitempty"`
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
 	// Specifies the address family (ipv4, ipv6 or any) for which the rules are generated. If unspecified, matches
 	// the address family of other options in this section and defaults to any.
-	Family *string `json:"family,omitempty"`
+	Family uci.Optional[string] `json:"family,omitzero"`
 	// Policy (ACCEPT, REJECT, DROP) for forwarded zone traffic.
-	Forward *string `json:"forward,omitempty"`
+	Forward uci.Optional[string] `json:"forward,omitzero"`
 	// List of helpers to add to zone.
-	Helper *uci.List `json:"helper,omitempty"`
+	Helper uci.Optional[uci.List] `json:"helper,omitzero"`
 	// Policy (ACCEPT, REJECT, DROP) for incoming zone traffic.
-	Input *string `json:"input,omitempty"`
+	Input uci.Optional[string] `json:"input,omitzero"`
 	// Bit field to enable logging in the filter and/or mangle tables, bit 0 = filter, bit 1 = mangle.
-	Log uci.Int `json:"log,omitempty"`
+	Log uci.Optional[uci.Int] `json:"log,omitzero"`
 	// Limits the amount of log messages per interval.
-	LogLimit *string `json:"log_limit,omitempty"`
+	LogLimit uci.Optional[string] `json:"log_limit,omitzero"`
 	// Specifies whether outgoing zone IPv4 traffic should be masqueraded. This is typically enabled on the wan zone.
-	Masq *uci.Bool `json:"masq,omitempty"`
+	Masq uci.Optional[uci.Bool] `json:"masq,omitzero"`
 	// Specifies whether outgoing zone IPv6 traffic should be masqueraded. This is typically enabled on the wan zone.
 	// Available with fw4. Requires sourcefilter=0 for DHCPv6 interfaces with missing GUA prefix.
-	Masq6 *uci.Bool `json:"masq6,omitempty"`
+	Masq6 uci.Optional[uci.Bool] `json:"masq6,omitzero"`
 	// Do not add DROP INVALID rules, if masquerading is used. The DROP rules are supposed to prevent NAT leakage.
-	MasqAllowInvalid *uci.Bool `json:"masq_allow_invalid,omitempty"`
+	MasqAllowInvalid uci.Optional[uci.Bool] `json:"masq_allow_invalid,omitzero"`
 	// Limit masquerading to the given destination subnets. Negation is possible by prefixing the subnet with !;
 	// multiple subnets are allowed.
-	MasqDest *uci.List `json:"masq_dest,omitempty"`
+	MasqDest uci.Optional[uci.List] `json:"masq_dest,omitzero"`
 	// Limit masquerading to the given source subnets. Negation is possible by prefixing the subnet with !; multiple
 	// subnets are allowed.
-	MasqSrc *uci.List `json:"masq_src,omitempty"`
+	MasqSrc uci.Optional[uci.List] `json:"masq_src,omitzero"`
 	// Enable MSS clamping for outgoing zone traffic.
-	MTUFix *uci.Bool `json:"mtu_fix,omitempty"`
+	MTUFix uci.Optional[uci.Bool] `json:"mtu_fix,omitzero"`
 	// Unique zone name. 11 characters is the maximum working firewall zone name length.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// List of interfaces attached to this zone. If omitted and neither extra* options, subnets nor devices are given,
 	// the value of name is used by default. Alias interfaces defined in the network config cannot be used as valid
 	// 'standalone' networks. Use list syntax.
-	Network *uci.List `json:"network,omitempty"`
+	Network uci.Optional[uci.List] `json:"network,omitzero"`
 	// Policy (ACCEPT, REJECT, DROP) for outgoing zone traffic.
-	Output *string `json:"output,omitempty"`
+	Output uci.Optional[string] `json:"output,omitzero"`
 	// List of IP subnets attached to this zone.
-	Subnet *uci.List `json:"subnet,omitempty"`
+	Subnet uci.Optional[uci.List] `json:"subnet,omitzero"`
 }
 
 func (ZoneSectionOptions) IsConfigSectionOptions() {}
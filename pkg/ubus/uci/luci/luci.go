@@ -0,0 +1,107 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package luci
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "luci"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	Core     = "core"
+	Extern   = "extern"
+	Internal = "internal"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{Core, Extern, Internal}
+
+	uci.RegisterSection[CoreSection, CoreSectionOptions](Config, Core)
+	// extern sections are not registered: all of their options are named by the user, e.g.
+	// "flash_keep" maps names to paths which are kept during sysupgrade, so there is nothing to
+	// model and a typed section would always be empty. they decode as a uci.GenericSection
+	uci.RegisterSection[InternalSection, InternalSectionOptions](Config, Internal)
+}
+
+// the "main" section, general settings of the web interface
+type CoreSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	CoreSectionOptions       `json:",inline"`
+}
+
+func (in *CoreSection) DeepCopyInto(out *CoreSection) {
+	*out = *in
+}
+
+type CoreSectionOptions struct {
+	// Language of the web interface, e.g. "en", or "auto" to use the browser's language.
+	Lang uci.Optional[string] `json:"lang,omitzero"`
+	// URL path of the theme to use, e.g. "/luci-static/bootstrap".
+	MediaURLBase uci.Optional[string] `json:"mediaurlbase,omitzero"`
+	// URL path of the static resources.
+	ResourceBase uci.Optional[string] `json:"resourcebase,omitzero"`
+	// URL path of the ubus JSON-RPC endpoint.
+	UbusPath uci.Optional[string] `json:"ubuspath,omitzero"`
+}
+
+func (CoreSectionOptions) IsConfigSectionOptions() {}
+
+// sections used internally by LuCI, e.g. "sauth", "ccache", "apply" and "diag". each of them only uses
+// the options documented for it. the options of "languages" and "themes" are named by the user, e.g.
+// "Bootstrap" mapping a theme to its path, and are not modeled: UnmarshalSection (and so a `uci get`)
+// drops them, uci.UnmarshalSectionLossless (snapshots, reconcile, uciformat) keeps those two sections
+// as a uci.GenericSection
+type InternalSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	InternalSectionOptions   `json:",inline"`
+}
+
+func (in *InternalSection) DeepCopyInto(out *InternalSection) {
+	*out = *in
+}
+
+type InternalSectionOptions struct {
+	// (apply) Seconds to wait for the router to come back after applying before rolling back.
+	Rollback uci.Optional[uci.Int] `json:"rollback,omitzero"`
+	// (apply) Seconds to wait after applying before reloading the page.
+	Holdoff uci.Optional[uci.Int] `json:"holdoff,omitzero"`
+	// (apply) Seconds to wait for services to restart.
+	Timeout uci.Optional[uci.Int] `json:"timeout,omitzero"`
+	// (apply) Seconds to show the apply dialog, may be fractional, e.g. "1.5".
+	Display uci.Optional[string] `json:"display,omitzero"`
+	// (ccache) Whether to cache compiled Lua modules.
+	Enable uci.Optional[uci.Bool] `json:"enable,omitzero"`
+	// (diag) Default host for the nslookup diagnostic.
+	DNS uci.Optional[string] `json:"dns,omitzero"`
+	// (diag) Default host for the ping diagnostic.
+	Ping uci.Optional[string] `json:"ping,omitzero"`
+	// (diag) Default host for the traceroute diagnostic.
+	Route uci.Optional[string] `json:"route,omitzero"`
+	// (sauth) Directory to store sessions in.
+	SessionPath uci.Optional[string] `json:"sessionpath,omitzero"`
+	// (sauth) Session timeout in seconds.
+	SessionTime uci.Optional[uci.Int] `json:"sessiontime,omitzero"`
+}
+
+func (InternalSectionOptions) IsConfigSectionOptions() {}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package luci
+
+import (
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestUnmarshalSectionLossless(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		typed bool
+	}{
+		{"sauth", `{".anonymous":false,".type":"internal",".name":"sauth","sessionpath":"/tmp/luci-sessions","sessiontime":"3600"}`, true},
+		{"apply", `{".anonymous":false,".type":"internal",".name":"apply","rollback":"90","holdoff":"4","timeout":"5","display":"1.5"}`, true},
+		{"themes", `{".anonymous":false,".type":"internal",".name":"themes","Bootstrap":"/luci-static/bootstrap"}`, false},
+		{"flash_keep", `{".anonymous":false,".type":"extern",".name":"flash_keep","uci":"/etc/config/"}`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			section, err := uci.UnmarshalSectionLossless(Config, []byte(test.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := section.(InternalSection); ok != test.typed {
+				t.Errorf("\nexpected typed: %v\nactual: %T", test.typed, section)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mwan3
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "mwan3"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	Globals   = "globals"
+	Interface = "interface"
+	Member    = "member"
+	Policy    = "policy"
+	Rule      = "rule"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{Globals, Interface, Member, Policy, Rule}
+
+	uci.RegisterSection[GlobalsSection, GlobalsSectionOptions](Config, Globals)
+	uci.RegisterSection[InterfaceSection, InterfaceSectionOptions](Config, Interface)
+	uci.RegisterSection[MemberSection, MemberSectionOptions](Config, Member)
+	uci.RegisterSection[PolicySection, PolicySectionOptions](Config, Policy)
+	uci.RegisterSection[RuleSection, RuleSectionOptions](Config, Rule)
+}
+
+type GlobalsSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	GlobalsSectionOptions    `json:",inline"`
+}
+
+func (in *GlobalsSection) DeepCopyInto(out *GlobalsSection) {
+	*out = *in
+}
+
+type GlobalsSectionOptions struct {
+	// Firewall mask reserved for mwan3, must not overlap with other marks in use.
+	MMXMask uci.Optional[string] `json:"mmx_mask,omitzero"`
+	// Interval in seconds in which mwan3rtmon syncs the main routing table to the interface tables.
+	RTMonInterval uci.Optional[uci.Int] `json:"rtmon_interval,omitzero"`
+	// Whether to sync IPv6 routes too.
+	RTMonIPv6 uci.Optional[uci.Bool] `json:"rtmon_ipv6,omitzero"`
+	// Whether to log rule matches.
+	Logging uci.Optional[uci.Bool] `json:"logging,omitzero"`
+	// Syslog level of the logged rule matches, e.g. "notice".
+	LogLevel uci.Optional[string] `json:"loglevel,omitzero"`
+}
+
+func (GlobalsSectionOptions) IsConfigSectionOptions() {}
+
+// a WAN interface tracked by mwan3, the section name must match a network interface
+type InterfaceSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	InterfaceSectionOptions  `json:",inline"`
+}
+
+func (in *InterfaceSection) DeepCopyInto(out *InterfaceSection) {
+	*out = *in
+}
+
+type InterfaceSectionOptions struct {
+	// Whether to check the link quality (latency and loss) in addition to reachability.
+	CheckQuality uci.Optional[uci.Bool] `json:"check_quality,omitzero"`
+	// Number of checks sent to each track_ip per test.
+	Count uci.Optional[uci.Int] `json:"count,omitzero"`
+	// Number of failed tests after which the interface is considered offline.
+	Down uci.Optional[uci.Int] `json:"down,omitzero"`
+	// Whether mwan3 manages the interface.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Latency in milliseconds above which a check fails, with check_quality.
+	FailureLatency uci.Optional[uci.Int] `json:"failure_latency,omitzero"`
+	// Loss in percent above which a check fails, with check_quality.
+	FailureLoss uci.Optional[uci.Int] `json:"failure_loss,omitzero"`
+	// Interval in seconds between tests while the interface is failing.
+	FailureInterval uci.Optional[uci.Int] `json:"failure_interval,omitzero"`
+	// Address family of the interface, "ipv4" or "ipv6".
+	Family uci.Optional[string] `json:"family,omitzero"`
+	// When to flush the conntrack table, e.g. "ifup", "ifdown", "connected", "disconnected".
+	FlushConntrack uci.Optional[uci.List] `json:"flush_conntrack,omitzero"`
+	// State the interface is assumed to be in before the first test, "online" or "offline".
+	InitialState uci.Optional[string] `json:"initial_state,omitzero"`
+	// Interval in seconds between tests.
+	Interval uci.Optional[uci.Int] `json:"interval,omitzero"`
+	// Whether to keep using failure_interval while the interface is offline.
+	KeepFailureInterval uci.Optional[uci.Bool] `json:"keep_failure_interval,omitzero"`
+	// Maximum TTL of the checks, for track_method ping.
+	MaxTTL uci.Optional[uci.Int] `json:"max_ttl,omitzero"`
+	// Latency in milliseconds below which a check recovers, with check_quality.
+	RecoveryLatency uci.Optional[uci.Int] `json:"recovery_latency,omitzero"`
+	// Loss in percent below which a check recovers, with check_quality.
+	RecoveryLoss uci.Optional[uci.Int] `json:"recovery_loss,omitzero"`
+	// Interval in seconds between tests while the interface is recovering.
+	RecoveryInterval uci.Optional[uci.Int] `json:"recovery_interval,omitzero"`
+	// Number of track_ip hosts which have to answer for a test to succeed.
+	Reliability uci.Optional[uci.Int] `json:"reliability,omitzero"`
+	// Payload size in bytes of the checks, for track_method ping.
+	Size uci.Optional[uci.Int] `json:"size,omitzero"`
+	// Timeout in seconds of each check.
+	Timeout uci.Optional[uci.Int] `json:"timeout,omitzero"`
+	// Hosts to check the interface's connectivity against.
+	TrackIP uci.Optional[uci.List] `json:"track_ip,omitzero"`
+	// Tool used for the checks: "ping", "arping", "httping" or "nping-<proto>".
+	TrackMethod uci.Optional[string] `json:"track_method,omitzero"`
+	// Number of successful tests after which the interface is considered online.
+	Up uci.Optional[uci.Int] `json:"up,omitzero"`
+}
+
+func (InterfaceSectionOptions) IsConfigSectionOptions() {}
+
+// an interface with a metric and weight, policies are made of members
+type MemberSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	MemberSectionOptions     `json:",inline"`
+}
+
+func (in *MemberSection) DeepCopyInto(out *MemberSection) {
+	*out = *in
+}
+
+type MemberSectionOptions struct {
+	// Name of the mwan3 interface.
+	Interface uci.Optional[string] `json:"interface,omitzero"`
+	// Members with a lower metric are used first, the others are only used for failover.
+	Metric uci.Optional[uci.Int] `json:"metric,omitzero"`
+	// Share of traffic balanced to this member among members with the same metric.
+	Weight uci.Optional[uci.Int] `json:"weight,omitzero"`
+}
+
+func (MemberSectionOptions) IsConfigSectionOptions() {}
+
+type PolicySection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	PolicySectionOptions     `json:",inline"`
+}
+
+func (in *PolicySection) DeepCopyInto(out *PolicySection) {
+	*out = *in
+}
+
+type PolicySectionOptions struct {
+	// What to do with traffic when no member is online: "unreachable", "blackhole" or "default".
+	LastResort uci.Optional[string] `json:"last_resort,omitzero"`
+	// Names of the members traffic matched by this policy is sent through.
+	UseMember uci.Optional[uci.List] `json:"use_member,omitzero"`
+}
+
+func (PolicySectionOptions) IsConfigSectionOptions() {}
+
+type RuleSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RuleSectionOptions       `json:",inline"`
+}
+
+func (in *RuleSection) DeepCopyInto(out *RuleSection) {
+	*out = *in
+}
+
+type RuleSectionOptions struct {
+	// Match traffic directed to the given destination IP address or subnet.
+	DestIP uci.Optional[string] `json:"dest_ip,omitzero"`
+	// Match traffic directed to the given destination port or port range.
+	DestPort uci.Optional[string] `json:"dest_port,omitzero"`
+	// Address family of the rule, "ipv4", "ipv6" or "any".
+	Family uci.Optional[string] `json:"family,omitzero"`
+	// Match traffic against the given ipset.
+	IPSet uci.Optional[string] `json:"ipset,omitzero"`
+	// Whether to log matched traffic, requires logging in the globals section.
+	Logging uci.Optional[uci.Bool] `json:"logging,omitzero"`
+	// Match traffic using the given protocol, e.g. "tcp", "udp" or "all".
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// Match traffic from the given source IP address or subnet.
+	SrcIP uci.Optional[string] `json:"src_ip,omitzero"`
+	// Match traffic from the given source port or port range.
+	SrcPort uci.Optional[string] `json:"src_port,omitzero"`
+	// Whether to keep sending traffic from the same source through the same interface.
+	Sticky uci.Optional[uci.Bool] `json:"sticky,omitzero"`
+	// Seconds a sticky assignment is kept.
+	Timeout uci.Optional[uci.Int] `json:"timeout,omitzero"`
+	// Name of the policy matched traffic is sent through, or "default" to use the main routing table.
+	UsePolicy uci.Optional[string] `json:"use_policy,omitzero"`
+}
+
+func (RuleSectionOptions) IsConfigSectionOptions() {}
@@ -17,6 +17,9 @@ limitations under the License.
 package network
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
 )
 
@@ -29,17 +32,71 @@ const (
 	Device     = "device"
 	Globals    = "globals"
 	Interface  = "interface"
+	Route      = "route"
+	Route6     = "route6"
+	Rule       = "rule"
+	Rule6      = "rule6"
 	Switch     = "switch"
 	SwitchPort = "switch_port"
 	SwitchVLAN = "switch_vlan"
+	// pattern of the per-interface WireGuard peer types, the actual type is "wireguard_<iface>",
+	// see WireGuardPeerType
+	WireGuardPeer = "wireguard_*"
 )
 
 var (
 	Sections []string
+	// the protocol-specific InterfaceSectionOptions which are valid for each proto, keyed by proto.
+	// options which are not listed for any proto (e.g. device, mtu, ip6assign) are valid for all
+	// of them
+	ProtocolOptions map[string][]string
 )
 
 func init() {
-	Sections = []string{Device, Globals, Interface, Switch, SwitchVLAN}
+	Sections = []string{Device, Globals, Interface, Route, Route6, Rule, Rule6, Switch, SwitchVLAN, WireGuardPeer}
+
+	ppp := []string{"defaultroute", "demand", "keepalive", "password", "peerdns", "pppd_options", "username"}
+	gre := []string{"icsum", "ikey", "iseqno", "ocsum", "okey", "oseqno", "tos", "ttl", "tunlink"}
+	ProtocolOptions = map[string][]string{
+		"3g":        slices.Concat(ppp, []string{"apn", "dialnumber", "pincode", "service"}),
+		"6in4":      {"ipaddr", "ip6prefix", "password", "peeraddr", "tos", "ttl", "tunlink", "tunnelid", "updatekey", "username"},
+		"6rd":       {"ipaddr", "ip4prefixlen", "ip6prefix", "ip6prefixlen", "peeraddr", "tos", "ttl", "tunlink"},
+		"6to4":      {"ipaddr", "tos", "ttl", "tunlink"},
+		"dhcp":      {"broadcast", "classlessroute", "clientid", "defaultroute", "hostname", "peerdns", "reqopts", "sendopts", "vendorid"},
+		"dhcpv6":    {"clientid", "defaultroute", "ip6prefix", "peerdns", "reqaddress", "reqopts", "reqprefix"},
+		"dslite":    {"encaplimit", "ip6addr", "peeraddr", "ttl", "tunlink"},
+		"gre":       slices.Concat(gre, []string{"df", "ipaddr", "peeraddr"}),
+		"gretap":    slices.Concat(gre, []string{"df", "ipaddr", "peeraddr"}),
+		"grev6":     slices.Concat(gre, []string{"ip6addr", "peer6addr"}),
+		"grev6tap":  slices.Concat(gre, []string{"ip6addr", "peer6addr"}),
+		"l2tp":      slices.Concat(ppp, []string{"server"}),
+		"none":      {},
+		"ppp":       ppp,
+		"pppoa":     slices.Concat(ppp, []string{"encaps", "vci", "vpi"}),
+		"pppoe":     slices.Concat(ppp, []string{"ac", "host_uniq", "service"}),
+		"pptp":      slices.Concat(ppp, []string{"server"}),
+		"static":    {"broadcast", "gateway", "ipaddr", "ip6addr", "ip6gw", "ip6prefix", "netmask"},
+		"vxlan":     {"ipaddr", "peeraddr", "port", "rxcsum", "tos", "ttl", "tunlink", "txcsum", "vid"},
+		"wireguard": {"addresses", "fwmark", "ip6prefix", "listen_port", "nohostroute", "private_key"},
+	}
+
+	uci.RegisterSection[BridgeVLANSection, BridgeVLANSectionOptions](Config, BridgeVLAN)
+	uci.RegisterSection[DeviceSection, DeviceSectionOptions](Config, Device)
+	uci.RegisterSection[GlobalsSection, GlobalsSectionOptions](Config, Globals)
+	uci.RegisterSection[InterfaceSection, InterfaceSectionOptions](Config, Interface)
+	uci.RegisterSection[RouteSection, RouteSectionOptions](Config, Route)
+	uci.RegisterSection[Route6Section, Route6SectionOptions](Config, Route6)
+	uci.RegisterSection[RuleSection, RuleSectionOptions](Config, Rule)
+	uci.RegisterSection[Rule6Section, RuleSectionOptions](Config, Rule6)
+	uci.RegisterSection[SwitchSection, SwitchSectionOptions](Config, Switch)
+	uci.RegisterSection[SwitchPortSection, SwitchPortSectionOptions](Config, SwitchPort)
+	uci.RegisterSection[SwitchVLANSection, SwitchVLANSectionOptions](Config, SwitchVLAN)
+	uci.RegisterSection[WireGuardPeerSection, WireGuardPeerSectionOptions](Config, WireGuardPeer)
+}
+
+// returns the section type of the peers of the WireGuard interface iface
+func WireGuardPeerType(iface string) string {
+	return "wireguard_" + iface
 }
 
 // used by InterfaceSection.Proto
@@ -70,6 +127,7 @@ var Protocols = []string{
 	"vti",
 	"vtiv6",
 	"vxlan",
+	"wireguard",
 	"wwan",
 }
 
@@ -84,12 +142,12 @@ func (in *BridgeVLANSection) DeepCopyInto(out *BridgeVLANSection) {
 
 type BridgeVLANSectionOptions struct {
 	// The name of the device to associated the bridge VLAN with
-	Device *string `json:"device,omitempty"`
+	Device uci.Optional[string] `json:"device,omitzero"`
 	// A list of ports and whether tagged or untagged, each list entry must be of the
 	// format 'lan1:t' or 'lan2:u*'
-	Ports *uci.List `json:"ports,omitempty"`
+	Ports uci.Optional[uci.List] `json:"ports,omitzero"`
 	// The VLAN ID
-	VLAN *uci.Int `json:"vlan,omitempty"`
+	VLAN uci.Optional[uci.Int] `json:"vlan,omitzero"`
 }
 
 func (BridgeVLANSectionOptions) IsConfigSectionOptions() {}
@@ -105,23 +163,23 @@ func (in *DeviceSection) DeepCopyInto(out *DeviceSection) {
 
 type DeviceSectionOptions struct {
 	// Enables auto-negotiation of link parameters like speed and duplex.
-	Autoneg *string `json:"autoneg,omitempty"`
+	Autoneg uci.Optional[string] `json:"autoneg,omitzero"`
 	// Base L2 device (required for macvlan type).
-	IfName *string `json:"ifname,omitempty"`
+	IfName uci.Optional[string] `json:"ifname,omitzero"`
 	// MAC address override for the device (e.g., 62:11:22:aa:bb:cc).
-	MACAddr *string `json:"macaddr,omitempty"`
+	MACAddr uci.Optional[string] `json:"macaddr,omitzero"`
 	// Logical name of the L3 device; must match the interface's device option.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// List of L2 device names to be included in a bridge.
-	Ports *uci.List `json:"ports,omitempty"`
+	Ports uci.Optional[uci.List] `json:"ports,omitzero"`
 	// Controls receive (RX) flow control. "1" enables RX pause frames.
-	RxPause *string `json:"rxpause,omitempty"`
+	RxPause uci.Optional[string] `json:"rxpause,omitzero"`
 	// Set routing table name or number for type=vrf.
-	Table *string `json:"table,omitempty"`
+	Table uci.Optional[string] `json:"table,omitzero"`
 	// Device type, e.g., "bridge" or "vrf".
-	Type *string `json:"type,omitempty"`
+	Type uci.Optional[string] `json:"type,omitzero"`
 	// Controls transmission (TX) flow control. "1" enables TX pause frames.
-	TxPause *string `json:"txpause,omitempty"`
+	TxPause uci.Optional[string] `json:"txpause,omitzero"`
 }
 
 func (DeviceSectionOptions) IsConfigSectionOptions() {}
@@ -138,13 +196,13 @@ func (in *GlobalsSection) DeepCopyInto(out *GlobalsSection) {
 type GlobalsSectionOptions struct {
 	// Enables packet steering across CPUs:
 	// 0 = disabled, 1 = enabled, 2 = enabled for all CPUs.
-	PacketSteering *uci.Int `json:"packet_steering,omitempty"`
+	PacketSteering uci.Optional[uci.Int] `json:"packet_steering,omitzero"`
 	// Toggles net.ipv4.tcp_l3mdev_accept (for VRF).
-	TCPL3Mdev *uci.Bool `json:"tcp_l3mdev,omitempty"`
+	TCPL3Mdev uci.Optional[uci.Bool] `json:"tcp_l3mdev,omitzero"`
 	// Toggles net.ipv4.udp_l3mdev_accept (for VRF).
-	UDPL3Mdev *uci.Bool `json:"udp_l3mdev,omitempty"`
+	UDPL3Mdev uci.Optional[uci.Bool] `json:"udp_l3mdev,omitzero"`
 	// IPv6 ULA prefix; set to "auto" to generate automatically.
-	ULAPrefix *string `json:"ula_prefix,omitempty"`
+	ULAPrefix uci.Optional[string] `json:"ula_prefix,omitzero"`
 }
 
 func (GlobalsSectionOptions) IsConfigSectionOptions() {}
@@ -160,61 +218,365 @@ func (in *InterfaceSection) DeepCopyInto(out *InterfaceSection) {
 
 type InterfaceSectionOptions struct {
 	// Whether to bring up the interface on boot.
-	Auto *uci.Bool `json:"auto,omitempty"`
+	Auto uci.Optional[uci.Bool] `json:"auto,omitzero"`
 	// Whether to disable the interface section entirely.
-	Disabled *uci.Bool `json:"disabled,omitempty"`
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
 	// Name of the associated L3 device (e.g., eth0.1, br-lan, tun0).
 	// Must match the name in the corresponding device section.
-	Device *string `json:"device,omitempty"`
+	Device uci.Optional[string] `json:"device,omitzero"`
 	// Whether to assign IP settings even if the link is down.
-	ForceLink *uci.Bool `json:"force_link,omitempty"`
+	ForceLink uci.Optional[uci.Bool] `json:"force_link,omitzero"`
 	// Enables or disables IPv6 support on this interface.
-	IPv6 *uci.Bool `json:"ipv6,omitempty"`
+	IPv6 uci.Optional[uci.Bool] `json:"ipv6,omitzero"`
 	// Name or number of the IPv4 routing table for this interface.
-	IP4Table *string `json:"ip4table,omitempty"`
+	IP4Table uci.Optional[string] `json:"ip4table,omitzero"`
 	// Name or number of the IPv6 routing table for this interface.
-	IP6Table *string `json:"ip6table,omitempty"`
+	IP6Table uci.Optional[string] `json:"ip6table,omitzero"`
 	// Override the default MTU for this interface.
-	MTU *uci.Int `json:"mtu,omitempty"`
+	MTU uci.Optional[uci.Int] `json:"mtu,omitzero"`
+	// Override the MAC address of the interface's device.
+	MACAddr uci.Optional[string] `json:"macaddr,omitzero"`
+	// Metric of the routes (e.g. the default route) added for this interface.
+	Metric uci.Optional[uci.Int] `json:"metric,omitzero"`
+	// The protocol used to configure the interface, one of Protocols. Selects which of the
+	// protocol-specific options below are valid, see ProtocolOptions.
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// DNS servers to use; with dhcp, dhcpv6 and ppp-based protos these are used in addition to
+	// the announced ones unless peerdns is disabled.
+	DNS uci.Optional[uci.List] `json:"dns,omitzero"`
+	// Search domains to use.
+	DNSSearch uci.Optional[uci.List] `json:"dns_search,omitzero"`
+	// Length of the prefix delegated from upstream to assign to this interface, with any proto.
+	IP6Assign uci.Optional[uci.Int] `json:"ip6assign,omitzero"`
+	// Hint for the subprefix ID of the delegated prefix assigned to this interface.
+	IP6Hint uci.Optional[string] `json:"ip6hint,omitzero"`
+	// Interface ID suffix used for IPv6 addresses, or "eui64" or "random".
+	IP6IfaceID uci.Optional[string] `json:"ip6ifaceid,omitzero"`
 
 	//
 	// bridge options
 	//
 
 	// Ageing time (in seconds) for dynamic MAC entries in the filtering database.
-	AgeingTime *uci.Int `json:"ageing_time,omitempty"`
+	AgeingTime uci.Optional[uci.Int] `json:"ageing_time,omitzero"`
 	// Whether to allow creating bridges with no ports.
-	BridgeEmpty *bool `json:"bridge_empty,omitempty"`
+	BridgeEmpty uci.Optional[uci.Bool] `json:"bridge_empty,omitzero"`
 	// Delay (in seconds) between port state transitions (STP); default 2, min 4 recommended.
-	ForwardDelay *uci.Int `json:"forward_delay,omitempty"`
+	ForwardDelay uci.Optional[uci.Int] `json:"forward_delay,omitzero"`
 	// Size of the kernel multicast hash table.
-	HashMax *uci.Int `json:"hash_max,omitempty"`
+	HashMax uci.Optional[uci.Int] `json:"hash_max,omitzero"`
 	// Interval (in 1/100s) between IGMP general queries.
-	HelloTime *uci.Int `json:"hello_time,omitempty"`
+	HelloTime uci.Optional[uci.Int] `json:"hello_time,omitzero"`
 	// Enables IGMP snooping to optimize multicast traffic distribution.
-	IGMPSnooping *bool `json:"igmp_snooping,omitempty"`
+	IGMPSnooping uci.Optional[uci.Bool] `json:"igmp_snooping,omitzero"`
 	// Enables the bridge as an IGMP querier.
-	MulticastQuerier *bool `json:"multicast_querier,omitempty"`
+	MulticastQuerier uci.Optional[uci.Bool] `json:"multicast_querier,omitzero"`
 	// Maximum age (in seconds) before trying to become Root Bridge (STP).
-	MaxAge *uci.Int `json:"max_age,omitempty"`
+	MaxAge uci.Optional[uci.Int] `json:"max_age,omitzero"`
 	// Priority value for the bridge (STP); lower is higher priority.
-	Priority *uci.Int `json:"priority,omitempty"`
+	Priority uci.Optional[uci.Int] `json:"priority,omitzero"`
 	// Interval (in 1/100s) for sending IGMP query responses after a leave group message.
-	LastMemberInterval *uci.Int `json:"last_member_interval,omitempty"`
+	LastMemberInterval uci.Optional[uci.Int] `json:"last_member_interval,omitzero"`
 	// Interval (in 1/100s) between IGMP general queries.
-	QueryInterval *uci.Int `json:"query_interval,omitempty"`
+	QueryInterval uci.Optional[uci.Int] `json:"query_interval,omitzero"`
 	// Interval (in 1/100s) within which IGMP query responses must be sent.
-	QueryResponseInterval *uci.Int `json:"query_response_interval,omitempty"`
+	QueryResponseInterval uci.Optional[uci.Int] `json:"query_response_interval,omitzero"`
 	// IGMP robustness value, influences query intervals and timeouts.
-	Robustness *uci.Int `json:"robustness,omitempty"`
+	Robustness uci.Optional[uci.Int] `json:"robustness,omitzero"`
 	// Enables the Spanning Tree Protocol (STP) to prevent network loops.
-	STP *uci.Bool `json:"stp,omitempty"`
+	STP uci.Optional[uci.Bool] `json:"stp,omitzero"`
 	// Enables VLAN-aware bridge mode.
-	VLANFiltering *uci.Bool `json:"vlan_filtering,omitempty"`
+	VLANFiltering uci.Optional[uci.Bool] `json:"vlan_filtering,omitzero"`
+
+	//
+	// static options
+	//
+
+	// IPv4 address(es), optionally in CIDR notation. Also the local endpoint of 6in4, 6rd, 6to4,
+	// gre, gretap and vxlan tunnels.
+	IPAddr uci.Optional[uci.List] `json:"ipaddr,omitzero"`
+	// IPv4 netmask, if ipaddr is not in CIDR notation.
+	Netmask uci.Optional[string] `json:"netmask,omitzero"`
+	// IPv4 default gateway.
+	Gateway uci.Optional[string] `json:"gateway,omitzero"`
+	// IPv4 broadcast address with static; with dhcp, whether to request broadcast replies.
+	Broadcast uci.Optional[string] `json:"broadcast,omitzero"`
+	// IPv6 address(es) in CIDR notation. Also the local endpoint of dslite, grev6 and grev6tap tunnels.
+	IP6Addr uci.Optional[uci.List] `json:"ip6addr,omitzero"`
+	// IPv6 default gateway.
+	IP6GW uci.Optional[string] `json:"ip6gw,omitzero"`
+	// IPv6 prefix(es) routed to this device for distribution to clients.
+	IP6Prefix uci.Optional[uci.List] `json:"ip6prefix,omitzero"`
+
+	//
+	// dhcp and dhcpv6 options
+	//
+
+	// Hostname to include in DHCP requests.
+	Hostname uci.Optional[string] `json:"hostname,omitzero"`
+	// Client identifier to include in DHCP requests, hex-encoded.
+	ClientID uci.Optional[string] `json:"clientid,omitzero"`
+	// Vendor class to include in DHCP requests.
+	VendorID uci.Optional[string] `json:"vendorid,omitzero"`
+	// Space-separated list of additional DHCP options to request.
+	ReqOpts uci.Optional[string] `json:"reqopts,omitzero"`
+	// Space-separated list of additional DHCP options to send.
+	SendOpts uci.Optional[string] `json:"sendopts,omitzero"`
+	// Whether to use the DNS servers announced by the server. Also valid for ppp-based protos.
+	PeerDNS uci.Optional[uci.Bool] `json:"peerdns,omitzero"`
+	// Whether to create a default route via the server. Also valid for ppp-based protos.
+	DefaultRoute uci.Optional[uci.Bool] `json:"defaultroute,omitzero"`
+	// Whether to use the classless static routes option (121) from the server.
+	ClasslessRoute uci.Optional[uci.Bool] `json:"classlessroute,omitzero"`
+	// Behaviour for requesting an IPv6 address: "try", "force" or "none".
+	ReqAddress uci.Optional[string] `json:"reqaddress,omitzero"`
+	// Behaviour for requesting an IPv6 prefix: "auto", "no" or a prefix length.
+	ReqPrefix uci.Optional[string] `json:"reqprefix,omitzero"`
+
+	//
+	// ppp, pppoe, pppoa, pptp, l2tp and 3g options
+	//
+
+	// Username for PAP/CHAP authentication. Also the tunnelbroker username for 6in4.
+	Username uci.Optional[string] `json:"username,omitzero"`
+	// Password for PAP/CHAP authentication. Also the tunnelbroker password for 6in4.
+	Password uci.Optional[string] `json:"password,omitzero"`
+	// Number of failed LCP echo requests and the interval between them, e.g. "5 1".
+	KeepAlive uci.Optional[string] `json:"keepalive,omitzero"`
+	// Seconds of inactivity after which to hang up, enables dial on demand.
+	Demand uci.Optional[uci.Int] `json:"demand,omitzero"`
+	// Additional command line arguments to pass to pppd.
+	PPPDOptions uci.Optional[string] `json:"pppd_options,omitzero"`
+	// Access concentrator to use (pppoe).
+	AC uci.Optional[string] `json:"ac,omitzero"`
+	// Service name to connect to (pppoe), or the service type, e.g. "umts" (3g).
+	Service uci.Optional[string] `json:"service,omitzero"`
+	// Raw hex value of the PPPoE host-uniq tag (pppoe).
+	HostUniq uci.Optional[string] `json:"host_uniq,omitzero"`
+	// ATM virtual circuit identifier (pppoa).
+	VCI uci.Optional[uci.Int] `json:"vci,omitzero"`
+	// ATM virtual path identifier (pppoa).
+	VPI uci.Optional[uci.Int] `json:"vpi,omitzero"`
+	// ATM encapsulation, "llc" or "vc" (pppoa).
+	Encaps uci.Optional[string] `json:"encaps,omitzero"`
+	// Remote server to connect to (pptp, l2tp).
+	Server uci.Optional[string] `json:"server,omitzero"`
+	// APN to use (3g).
+	APN uci.Optional[string] `json:"apn,omitzero"`
+	// PIN of the SIM card (3g).
+	PinCode uci.Optional[string] `json:"pincode,omitzero"`
+	// Number to dial (3g).
+	DialNumber uci.Optional[string] `json:"dialnumber,omitzero"`
+
+	//
+	// tunnel options (6in4, 6rd, 6to4, dslite, gre, gretap, grev6, grev6tap, vxlan)
+	//
+
+	// Remote IPv4 endpoint of the tunnel; the AFTR address for dslite (IPv6).
+	PeerAddr uci.Optional[string] `json:"peeraddr,omitzero"`
+	// Remote IPv6 endpoint of the tunnel (grev6, grev6tap).
+	Peer6Addr uci.Optional[string] `json:"peer6addr,omitzero"`
+	// Logical interface the tunnel is bound to.
+	TunLink uci.Optional[string] `json:"tunlink,omitzero"`
+	// TTL of the encapsulating packets.
+	TTL uci.Optional[uci.Int] `json:"ttl,omitzero"`
+	// TOS of the encapsulating packets, or "inherit".
+	TOS uci.Optional[string] `json:"tos,omitzero"`
+	// Tunnel ID of the tunnelbroker account (6in4).
+	TunnelID uci.Optional[string] `json:"tunnelid,omitzero"`
+	// Update key of the tunnelbroker account (6in4).
+	UpdateKey uci.Optional[string] `json:"updatekey,omitzero"`
+	// Length of the IPv6 prefix (6rd).
+	IP6PrefixLen uci.Optional[uci.Int] `json:"ip6prefixlen,omitzero"`
+	// Number of leading bits shared by all IPv4 addresses of the 6rd domain (6rd).
+	IP4PrefixLen uci.Optional[uci.Int] `json:"ip4prefixlen,omitzero"`
+	// IPv6 encapsulation limit, or "ignore" (dslite).
+	EncapLimit uci.Optional[string] `json:"encaplimit,omitzero"`
+	// Whether to set the don't fragment flag on encapsulating packets (gre, gretap).
+	DF uci.Optional[uci.Bool] `json:"df,omitzero"`
+	// Key for incoming packets (gre).
+	IKey uci.Optional[uci.Int] `json:"ikey,omitzero"`
+	// Key for outgoing packets (gre).
+	OKey uci.Optional[uci.Int] `json:"okey,omitzero"`
+	// Whether to require checksums on incoming packets (gre).
+	ICsum uci.Optional[uci.Bool] `json:"icsum,omitzero"`
+	// Whether to add checksums to outgoing packets (gre).
+	OCsum uci.Optional[uci.Bool] `json:"ocsum,omitzero"`
+	// Whether to require sequence numbers on incoming packets (gre).
+	ISeqNo uci.Optional[uci.Bool] `json:"iseqno,omitzero"`
+	// Whether to add sequence numbers to outgoing packets (gre).
+	OSeqNo uci.Optional[uci.Bool] `json:"oseqno,omitzero"`
+	// Destination UDP port (vxlan).
+	Port uci.Optional[uci.Int] `json:"port,omitzero"`
+	// VXLAN network identifier (vxlan).
+	VID uci.Optional[uci.Int] `json:"vid,omitzero"`
+	// Whether to verify checksums of incoming packets (vxlan).
+	RxCsum uci.Optional[uci.Bool] `json:"rxcsum,omitzero"`
+	// Whether to add checksums to outgoing packets (vxlan).
+	TxCsum uci.Optional[uci.Bool] `json:"txcsum,omitzero"`
+
+	//
+	// wireguard options
+	//
+
+	// Base64-encoded private key of the interface.
+	PrivateKey uci.Optional[string] `json:"private_key,omitzero"`
+	// UDP port to listen on for incoming connections; random if unset.
+	ListenPort uci.Optional[uci.Int] `json:"listen_port,omitzero"`
+	// IP addresses of the interface in CIDR notation.
+	Addresses uci.Optional[uci.List] `json:"addresses,omitzero"`
+	// Firewall mark to apply to outgoing tunnel packets, in hex.
+	FWMark uci.Optional[string] `json:"fwmark,omitzero"`
+	// Whether to skip adding a host route to the peer endpoints.
+	NoHostRoute uci.Optional[uci.Bool] `json:"nohostroute,omitzero"`
 }
 
 func (InterfaceSectionOptions) IsConfigSectionOptions() {}
 
+// rejects protocol-specific options which are not valid for o.Proto, see ProtocolOptions and
+// uci.ValidateDependentOptions. without a proto nothing is rejected, since options set on their
+// own (e.g. with `uci set`) belong to whatever proto the interface already has
+func (o InterfaceSectionOptions) Validate() error {
+	proto, ok := o.Proto.Get()
+	if !ok {
+		return nil
+	}
+	return uci.ValidateDependentOptions(o, "proto", proto, ProtocolOptions)
+}
+
+type RouteSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RouteSectionOptions      `json:",inline"`
+}
+
+func (in *RouteSection) DeepCopyInto(out *RouteSection) {
+	*out = *in
+}
+
+type RouteSectionOptions struct {
+	// Whether to disable the route.
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
+	// IPv4 gateway; if omitted, the gateway of the interface is used.
+	Gateway uci.Optional[string] `json:"gateway,omitzero"`
+	// Name of the logical interface the route belongs to.
+	Interface uci.Optional[string] `json:"interface,omitzero"`
+	// Metric of the route; routes with lower metrics are preferred.
+	Metric uci.Optional[uci.Int] `json:"metric,omitzero"`
+	// MTU of the route.
+	MTU uci.Optional[uci.Int] `json:"mtu,omitzero"`
+	// Network mask of the target; if omitted, 255.255.255.255 is assumed.
+	Netmask uci.Optional[string] `json:"netmask,omitzero"`
+	// Whether to assume the gateway is directly reachable even if no prefix matches it.
+	OnLink uci.Optional[uci.Bool] `json:"onlink,omitzero"`
+	// Routing protocol identifier of the route, by name or number.
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// Preferred source address when sending to destinations covered by the target.
+	Source uci.Optional[string] `json:"source,omitzero"`
+	// Name or number of the routing table to add the route to.
+	Table uci.Optional[string] `json:"table,omitzero"`
+	// Network address of the route, optionally in CIDR notation.
+	Target uci.Optional[string] `json:"target,omitzero"`
+	// Route type, e.g. "unicast", "local", "blackhole", "unreachable" or "prohibit".
+	Type uci.Optional[string] `json:"type,omitzero"`
+}
+
+func (RouteSectionOptions) IsConfigSectionOptions() {}
+
+type Route6Section struct {
+	uci.StaticSectionOptions `json:",inline"`
+	Route6SectionOptions     `json:",inline"`
+}
+
+func (in *Route6Section) DeepCopyInto(out *Route6Section) {
+	*out = *in
+}
+
+type Route6SectionOptions struct {
+	// Whether to disable the route.
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
+	// IPv6 gateway; if omitted, the gateway of the interface is used.
+	Gateway uci.Optional[string] `json:"gateway,omitzero"`
+	// Name of the logical interface the route belongs to.
+	Interface uci.Optional[string] `json:"interface,omitzero"`
+	// Metric of the route; routes with lower metrics are preferred.
+	Metric uci.Optional[uci.Int] `json:"metric,omitzero"`
+	// MTU of the route.
+	MTU uci.Optional[uci.Int] `json:"mtu,omitzero"`
+	// Whether to assume the gateway is directly reachable even if no prefix matches it.
+	OnLink uci.Optional[uci.Bool] `json:"onlink,omitzero"`
+	// Routing protocol identifier of the route, by name or number.
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// Preferred source address when sending to destinations covered by the target.
+	Source uci.Optional[string] `json:"source,omitzero"`
+	// Name or number of the routing table to add the route to.
+	Table uci.Optional[string] `json:"table,omitzero"`
+	// IPv6 network address of the route in CIDR notation.
+	Target uci.Optional[string] `json:"target,omitzero"`
+	// Route type, e.g. "unicast", "local", "blackhole", "unreachable" or "prohibit".
+	Type uci.Optional[string] `json:"type,omitzero"`
+}
+
+func (Route6SectionOptions) IsConfigSectionOptions() {}
+
+type RuleSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RuleSectionOptions       `json:",inline"`
+}
+
+func (in *RuleSection) DeepCopyInto(out *RuleSection) {
+	*out = *in
+}
+
+// options shared by RuleSection and Rule6Section
+type RuleSectionOptions struct {
+	// Routing action for matched traffic: "prohibit", "unreachable", "blackhole" or "throw".
+	Action uci.Optional[string] `json:"action,omitzero"`
+	// Destination subnet to match, in CIDR notation.
+	Dest uci.Optional[string] `json:"dest,omitzero"`
+	// Whether to disable the rule.
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
+	// Destination port or port range to match.
+	DPort uci.Optional[string] `json:"dport,omitzero"`
+	// Priority of the rule to jump to when the rule matches.
+	Goto uci.Optional[uci.Int] `json:"goto,omitzero"`
+	// Incoming logical interface to match.
+	In uci.Optional[string] `json:"in,omitzero"`
+	// Whether to invert the meaning of the match options.
+	Invert uci.Optional[uci.Bool] `json:"invert,omitzero"`
+	// IP protocol to match, by name or number.
+	IPProto uci.Optional[string] `json:"ipproto,omitzero"`
+	// Name or number of the routing table to look up when the rule matches.
+	Lookup uci.Optional[string] `json:"lookup,omitzero"`
+	// Firewall mark to match, optionally with a mask (e.g. "0x1/0xff").
+	Mark uci.Optional[string] `json:"mark,omitzero"`
+	// Outgoing logical interface to match.
+	Out uci.Optional[string] `json:"out,omitzero"`
+	// Ordering of the rule; rules with lower priorities are evaluated first.
+	Priority uci.Optional[uci.Int] `json:"priority,omitzero"`
+	// Source subnet to match, in CIDR notation.
+	Src uci.Optional[string] `json:"src,omitzero"`
+	// Source port or port range to match.
+	SPort uci.Optional[string] `json:"sport,omitzero"`
+	// Rejects routing decisions whose prefix length is this value or less.
+	SuppressPrefixLength uci.Optional[uci.Int] `json:"suppress_prefixlength,omitzero"`
+	// TOS value to match.
+	TOS uci.Optional[uci.Int] `json:"tos,omitzero"`
+	// User ID or range of user IDs to match (e.g. "1000-1005").
+	UIDRange uci.Optional[string] `json:"uidrange,omitzero"`
+}
+
+func (RuleSectionOptions) IsConfigSectionOptions() {}
+
+// identical to RuleSection but matches IPv6 traffic, all addresses have to be IPv6
+type Rule6Section struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RuleSectionOptions       `json:",inline"`
+}
+
+func (in *Rule6Section) DeepCopyInto(out *Rule6Section) {
+	*out = *in
+}
+
 type SwitchSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	SwitchSectionOptions     `json:",inline"`
@@ -226,25 +588,25 @@ func (in *SwitchSection) DeepCopyInto(out *SwitchSection) {
 
 type SwitchSectionOptions struct {
 	// Aging time (in seconds) for the ARL (MAC address) table. Default may differ by hardware.
-	ARLAgeTime *uci.Int `json:"arl_age_time,omitempty"`
+	ARLAgeTime uci.Optional[uci.Int] `json:"arl_age_time,omitzero"`
 	// Enables VLAN-aware mode on the switch. Default may differ by hardware.
-	EnableVLAN *uci.Bool `json:"enable_vlan,omitempty"`
+	EnableVLAN uci.Optional[uci.Bool] `json:"enable_vlan,omitzero"`
 	// Enables mirroring of received packets from source to monitor port.
-	EnableMirrorRX *uci.Bool `json:"enable_mirror_rx,omitempty"`
+	EnableMirrorRX uci.Optional[uci.Bool] `json:"enable_mirror_rx,omitzero"`
 	// Enables mirroring of transmitted packets from source to monitor port.
-	EnableMirrorTX *uci.Bool `json:"enable_mirror_tx,omitempty"`
+	EnableMirrorTX uci.Optional[uci.Bool] `json:"enable_mirror_tx,omitzero"`
 	// Enables IGMP snooping (behavior may vary).
-	IGMPSnooping *uci.Bool `json:"igmp_snooping,omitempty"`
+	IGMPSnooping uci.Optional[uci.Bool] `json:"igmp_snooping,omitzero"`
 	// Enables IGMPv3 support (behavior may vary).
-	IGMPv3 *uci.Bool `json:"igmp_v3,omitempty"`
+	IGMPv3 uci.Optional[uci.Bool] `json:"igmp_v3,omitzero"`
 	// Port to which mirrored packets are sent.
-	MirrorMonitorPort *uci.Int `json:"mirror_monitor_port,omitempty"`
+	MirrorMonitorPort uci.Optional[uci.Int] `json:"mirror_monitor_port,omitzero"`
 	// Port from which packets are mirrored.
-	MirrorSourcePort *uci.Int `json:"mirror_source_port,omitempty"`
+	MirrorSourcePort uci.Optional[uci.Int] `json:"mirror_source_port,omitzero"`
 	// Name of the switch being configured.
-	Name *string `json:"name,omitempty"`
+	Name uci.Optional[string] `json:"name,omitzero"`
 	// Whether to reset the switch configuration.
-	Reset *uci.Bool `json:"reset,omitempty"`
+	Reset uci.Optional[uci.Bool] `json:"reset,omitzero"`
 }
 
 func (SwitchSectionOptions) IsConfigSectionOptions() {}
@@ -260,18 +622,18 @@ func (in *SwitchPortSection) DeepCopyInto(out *SwitchPortSection) {
 
 type SwitchPortSectionOptions struct {
 	// Enables Energy Efficient Ethernet (EEE) features to save power.
-	EnableEEE *uci.Bool `json:"enable_eee,omitempty"`
+	EnableEEE uci.Optional[uci.Bool] `json:"enable_eee,omitzero"`
 	// Enables IGMP snooping on this port. Behavior may vary and is unconfirmed.
-	IGMPSnooping *uci.Bool `json:"igmp_snooping,omitempty"`
+	IGMPSnooping uci.Optional[uci.Bool] `json:"igmp_snooping,omitzero"`
 	// Enables IGMPv3 on this port. Behavior may vary and is unconfirmed.
-	IGMPv3 *uci.Bool `json:"igmp_v3,omitempty"`
+	IGMPv3 uci.Optional[uci.Bool] `json:"igmp_v3,omitzero"`
 	// Name of the switch device this port belongs to.
-	Device *string `json:"device,omitempty"`
+	Device uci.Optional[string] `json:"device,omitzero"`
 	// Index of the port to configure.
-	Port *uci.Int `json:"port,omitempty"`
+	Port uci.Optional[uci.Int] `json:"port,omitzero"`
 	// Port VLAN ID (PVID) to assign to untagged ingress packets.
 	// This may refer to VLAN index or tag depending on platform behavior.
-	PVID *uci.Int `json:"pvid,omitempty"`
+	PVID uci.Optional[uci.Int] `json:"pvid,omitzero"`
 }
 
 func (SwitchPortSectionOptions) IsConfigSectionOptions() {}
@@ -287,18 +649,56 @@ func (in *SwitchVLANSection) DeepCopyInto(out *SwitchVLANSection) {
 
 type SwitchVLANSectionOptions struct {
 	// A human-readable description of the VLAN configuration.
-	Description *string `json:"description,omitempty"`
+	Description uci.Optional[string] `json:"description,omitzero"`
 	// The switch device to configure (must match a defined switch).
-	Device *string `json:"device,omitempty"`
+	Device uci.Optional[string] `json:"device,omitzero"`
 	// A string of space-separated port indices associated with the VLAN.
 	// Use 't' suffix for tagged ports (e.g., "0 1 3t 5t").
-	Ports *string `json:"ports,omitempty"`
+	Ports uci.Optional[string] `json:"ports,omitzero"`
 	// VLAN tag number to use (VID); if unset, defaults to value of vlan.
 	// VLANs 0 and 4095 may have special meaning.
-	VID *uci.Int `json:"vid,omitempty"`
+	VID uci.Optional[uci.Int] `json:"vid,omitzero"`
 	// The VLAN table index to configure (not necessarily equal to VID).
 	// May be limited depending on hardware
-	VLAN *uci.Int `json:"vlan,omitempty"`
+	VLAN uci.Optional[uci.Int] `json:"vlan,omitzero"`
 }
 
 func (SwitchVLANSectionOptions) IsConfigSectionOptions() {}
+
+// a peer of a WireGuard interface, the section type is WireGuardPeerType(iface)
+type WireGuardPeerSection struct {
+	uci.StaticSectionOptions    `json:",inline"`
+	WireGuardPeerSectionOptions `json:",inline"`
+}
+
+func (in *WireGuardPeerSection) DeepCopyInto(out *WireGuardPeerSection) {
+	*out = *in
+}
+
+// returns the name of the WireGuard interface the peer belongs to
+func (s WireGuardPeerSection) Interface() string {
+	return strings.TrimPrefix(s.Type, "wireguard_")
+}
+
+type WireGuardPeerSectionOptions struct {
+	// IP addresses and prefixes the peer is allowed to send from and which are routed to it.
+	AllowedIPs uci.Optional[uci.List] `json:"allowed_ips,omitzero"`
+	// A description of the peer.
+	Description uci.Optional[string] `json:"description,omitzero"`
+	// Whether to disable the peer.
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
+	// Hostname or IP address of the peer; if unset, the peer has to connect first.
+	EndpointHost uci.Optional[string] `json:"endpoint_host,omitzero"`
+	// UDP port of the peer.
+	EndpointPort uci.Optional[uci.Int] `json:"endpoint_port,omitzero"`
+	// Seconds between keepalive packets, 0 disables them.
+	PersistentKeepalive uci.Optional[uci.Int] `json:"persistent_keepalive,omitzero"`
+	// Base64-encoded preshared key for an additional layer of symmetric encryption.
+	PresharedKey uci.Optional[string] `json:"preshared_key,omitzero"`
+	// Base64-encoded public key of the peer.
+	PublicKey uci.Optional[string] `json:"public_key,omitzero"`
+	// Whether to create routes for the allowed IPs.
+	RouteAllowedIPs uci.Optional[uci.Bool] `json:"route_allowed_ips,omitzero"`
+}
+
+func (WireGuardPeerSectionOptions) IsConfigSectionOptions() {}
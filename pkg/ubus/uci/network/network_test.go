@@ -0,0 +1,48 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestInterfaceValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		iface InterfaceSectionOptions
+		valid bool
+	}{
+		{"static", InterfaceSectionOptions{Proto: uci.Some("static"), IPAddr: uci.Some(uci.List{"192.168.1.1/24"}), IP6Assign: uci.Some[uci.Int](60)}, true},
+		{"dhcp option on static", InterfaceSectionOptions{Proto: uci.Some("static"), Hostname: uci.Some("router")}, false},
+		{"static option on dhcp", InterfaceSectionOptions{Proto: uci.Some("dhcp"), Gateway: uci.Some("192.168.1.254")}, false},
+		{"ip6 assignment on dhcpv6", InterfaceSectionOptions{Proto: uci.Some("dhcpv6"), IP6Assign: uci.Some[uci.Int](64), IP6Hint: uci.Some("10"), IP6IfaceID: uci.Some("eui64")}, true},
+		{"common options on wireguard", InterfaceSectionOptions{Proto: uci.Some("wireguard"), MTU: uci.Some[uci.Int](1420), IP6IfaceID: uci.Some("::1")}, true},
+		{"unknown proto", InterfaceSectionOptions{Proto: uci.Some("batadv"), Gateway: uci.Some("192.168.1.254")}, true},
+		// e.g. a `uci set` of a single option, the proto it belongs to is on the router
+		{"no proto", InterfaceSectionOptions{Gateway: uci.Some("192.168.1.254"), Hostname: uci.Some("router")}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := uci.Validate(test.iface); (err == nil) != test.valid {
+				t.Errorf("expected valid: %v, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
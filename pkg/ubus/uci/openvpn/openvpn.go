@@ -0,0 +1,141 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openvpn
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "openvpn"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	OpenVPN = "openvpn"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{OpenVPN}
+
+	uci.RegisterSection[OpenVPNSection, OpenVPNSectionOptions](Config, OpenVPN)
+}
+
+// a single OpenVPN instance. the options map to the openvpn(8) command line options of the same name
+// with dashes replaced by underscores; boolean flags are passed when set to 1
+type OpenVPNSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	OpenVPNSectionOptions    `json:",inline"`
+}
+
+func (in *OpenVPNSection) DeepCopyInto(out *OpenVPNSection) {
+	*out = *in
+}
+
+type OpenVPNSectionOptions struct {
+	// Message digest algorithm used to authenticate packets, e.g. "SHA256".
+	Auth uci.Optional[string] `json:"auth,omitzero"`
+	// File with the username and password to authenticate to the server with.
+	AuthUserPass uci.Optional[string] `json:"auth_user_pass,omitzero"`
+	// Path of the CA certificate.
+	CA uci.Optional[string] `json:"ca,omitzero"`
+	// Path of the local certificate.
+	Cert uci.Optional[string] `json:"cert,omitzero"`
+	// Cipher used for the data channel (deprecated by data_ciphers).
+	Cipher uci.Optional[string] `json:"cipher,omitzero"`
+	// Whether to run in client mode.
+	Client uci.Optional[uci.Bool] `json:"client,omitzero"`
+	// Whether to let clients reach each other through the server.
+	ClientToClient uci.Optional[uci.Bool] `json:"client_to_client,omitzero"`
+	// LZO compression: "yes", "no" or "adaptive".
+	CompLZO uci.Optional[string] `json:"comp_lzo,omitzero"`
+	// Path of an OpenVPN config file to use instead of the options of this section.
+	ConfigFile uci.Optional[string] `json:"config,omitzero"`
+	// Colon-separated list of ciphers negotiable for the data channel, e.g. "AES-256-GCM:AES-128-GCM".
+	DataCiphers uci.Optional[string] `json:"data_ciphers,omitzero"`
+	// The tun/tap device to use, e.g. "tun0" or "tun" for a dynamic one.
+	Dev uci.Optional[string] `json:"dev,omitzero"`
+	// Type of dev, "tun" or "tap", if it cannot be derived from its name.
+	DevType uci.Optional[string] `json:"dev_type,omitzero"`
+	// Path of the Diffie-Hellman parameters, or "none".
+	DH uci.Optional[string] `json:"dh,omitzero"`
+	// Whether to allow several clients with the same common name.
+	DuplicateCN uci.Optional[uci.Bool] `json:"duplicate_cn,omitzero"`
+	// Whether the instance is started.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Whether to accept packets from the peer even if its address changed.
+	Float uci.Optional[uci.Bool] `json:"float,omitzero"`
+	// Maximum size of UDP datagrams, larger packets are fragmented internally.
+	Fragment uci.Optional[uci.Int] `json:"fragment,omitzero"`
+	// Group to drop privileges to after startup.
+	Group uci.Optional[string] `json:"group,omitzero"`
+	// File to persist the IP addresses assigned to clients in.
+	IfconfigPoolPersist uci.Optional[string] `json:"ifconfig_pool_persist,omitzero"`
+	// Ping interval and timeout in seconds, e.g. "10 120".
+	KeepAlive uci.Optional[string] `json:"keepalive,omitzero"`
+	// Path of the local private key.
+	Key uci.Optional[string] `json:"key,omitzero"`
+	// File to write the log to instead of syslog.
+	Log uci.Optional[string] `json:"log,omitzero"`
+	// Maximum number of concurrently connected clients.
+	MaxClients uci.Optional[uci.Int] `json:"max_clients,omitzero"`
+	// Maximum size of TCP segments sent through the tunnel.
+	MSSFix uci.Optional[uci.Int] `json:"mssfix,omitzero"`
+	// Whether not to bind to a local address and port.
+	NoBind uci.Optional[uci.Bool] `json:"nobind,omitzero"`
+	// Whether to keep the keys across restarts caused by SIGUSR1 or ping-restart.
+	PersistKey uci.Optional[uci.Bool] `json:"persist_key,omitzero"`
+	// Whether to keep the tun/tap device across restarts caused by SIGUSR1 or ping-restart.
+	PersistTun uci.Optional[uci.Bool] `json:"persist_tun,omitzero"`
+	// Local port to bind to, and the remote port for remote entries without one.
+	Port uci.Optional[uci.Int] `json:"port,omitzero"`
+	// Transport protocol: "udp", "tcp-server" or "tcp-client" (and their "6" variants).
+	Proto uci.Optional[string] `json:"proto,omitzero"`
+	// Options pushed to clients, e.g. "route 192.168.1.0 255.255.255.0".
+	Push uci.Optional[uci.List] `json:"push,omitzero"`
+	// Servers to connect to in client mode, "host [port]".
+	Remote uci.Optional[uci.List] `json:"remote,omitzero"`
+	// Required usage of the peer certificate, "server" or "client".
+	RemoteCertTLS uci.Optional[string] `json:"remote_cert_tls,omitzero"`
+	// Seconds to retry resolving the remote hostnames, or "infinite".
+	ResolvRetry uci.Optional[string] `json:"resolv_retry,omitzero"`
+	// Routes to add when the tunnel is up, "network netmask".
+	Route uci.Optional[uci.List] `json:"route,omitzero"`
+	// Level of external programs allowed to be called, 0 to 3.
+	ScriptSecurity uci.Optional[uci.Int] `json:"script_security,omitzero"`
+	// Runs in server mode with the given tunnel subnet, "network netmask".
+	Server uci.Optional[string] `json:"server,omitzero"`
+	// File to write the status to, and optionally the interval in seconds, e.g. "/var/log/openvpn.status 10".
+	Status uci.Optional[string] `json:"status,omitzero"`
+	// Path of a static key used to add an HMAC signature to the TLS control channel, and its direction.
+	TLSAuth uci.Optional[string] `json:"tls_auth,omitzero"`
+	// Path of a static key used to encrypt and authenticate the TLS control channel.
+	TLSCrypt uci.Optional[string] `json:"tls_crypt,omitzero"`
+	// Topology of the tunnel subnet in server mode: "net30", "p2p" or "subnet".
+	Topology uci.Optional[string] `json:"topology,omitzero"`
+	// MTU of the tun device.
+	TunMTU uci.Optional[uci.Int] `json:"tun_mtu,omitzero"`
+	// User to drop privileges to after startup.
+	User uci.Optional[string] `json:"user,omitzero"`
+	// Log verbosity, 0 (silent) to 11.
+	Verb uci.Optional[uci.Int] `json:"verb,omitzero"`
+}
+
+func (OpenVPNSectionOptions) IsConfigSectionOptions() {}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uci
+
+import (
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// returns the zero value of a typed section, e.g. firewall.ZoneSection{}
+type SectionFactory func() ConfigSection
+
+// returns the zero value of typed section options, e.g. firewall.ZoneSectionOptions{}
+type OptionsFactory func() ConfigSectionOptions
+
+type sectionModel struct {
+	section SectionFactory
+	options OptionsFactory
+}
+
+var (
+	registryMu sync.RWMutex
+	// config -> section type (or pattern) -> model
+	registry = make(map[string]map[string]sectionModel)
+	// config -> section type patterns, in the order they were registered
+	registryPatterns = make(map[string][]string)
+)
+
+// registers the typed model of sectionType in config, replacing any earlier registration. sectionType
+// may also be a pattern (see path.Match) for types which embed a name, e.g. "wireguard_*", exact types
+// are always preferred over patterns. sections without a registered model are handled as GenericSection
+func Register(config, sectionType string, section SectionFactory, options OptionsFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if registry[config] == nil {
+		registry[config] = make(map[string]sectionModel)
+	}
+	if _, ok := registry[config][sectionType]; !ok && IsSectionTypePattern(sectionType) {
+		registryPatterns[config] = append(registryPatterns[config], sectionType)
+	}
+	registry[config][sectionType] = sectionModel{section: section, options: options}
+}
+
+// removes the model registered for sectionType in config, e.g. to undo a Register in a test
+func Unregister(config, sectionType string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	delete(registry[config], sectionType)
+	registryPatterns[config] = slices.DeleteFunc(registryPatterns[config], func(p string) bool { return p == sectionType })
+}
+
+// same as Register, with the factories derived from the type parameters
+func RegisterSection[S ConfigSection, O ConfigSectionOptions](config, sectionType string) {
+	Register(config, sectionType,
+		func() ConfigSection { var s S; return s },
+		func() ConfigSectionOptions { var o O; return o },
+	)
+}
+
+// reports whether sectionType is a pattern rather than an exact type, i.e. whether it contains any
+// of the path.Match metacharacters
+func IsSectionTypePattern(sectionType string) bool {
+	return strings.ContainsAny(sectionType, `*?[\`)
+}
+
+func lookup(config, sectionType string) (sectionModel, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	models := registry[config]
+	if model, ok := models[sectionType]; ok {
+		return model, true
+	}
+	model, ok := models[MatchSectionType(sectionType, registryPatterns[config]...)]
+	return model, ok
+}
+
+// returns the section type (or pattern) S is registered under in config, and whether it is registered.
+// if S is registered under several types, the first of them in sorted order is returned
+func SectionTypeOf[S ConfigSection](config string) (sectionType string, ok bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	want := reflect.TypeFor[S]()
+	for _, sectionType := range slices.Sorted(maps.Keys(registry[config])) {
+		if reflect.TypeOf(registry[config][sectionType].section()) == want {
+			return sectionType, true
+		}
+	}
+	return "", false
+}
+
+// returns the zero value of the typed section registered for sectionType in config, or a GenericSection
+func NewSection(config, sectionType string) ConfigSection {
+	if model, ok := lookup(config, sectionType); ok {
+		return model.section()
+	}
+	return GenericSection{}
+}
+
+// returns the zero value of the typed options registered for sectionType in config, or GenericSectionOptions
+func NewSectionOptions(config, sectionType string) ConfigSectionOptions {
+	if model, ok := lookup(config, sectionType); ok {
+		return model.options()
+	}
+	return GenericSectionOptions{}
+}
+
+// decodes a raw section of config, e.g. one value of a `uci get`, into the typed section registered
+// for its .type
+func UnmarshalSection(config string, data []byte) (ConfigSection, error) {
+	var probe struct {
+		Type string `json:".type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	section, err := unmarshalNew(NewSection(config, probe.Type), data)
+	if err != nil {
+		return nil, err
+	}
+	return section.(ConfigSection), nil
+}
+
+// same as UnmarshalSection, unless the typed model cannot parse data or does not know all of its
+// options, including the ones marked as deleted. those sections are decoded into a GenericSection
+// instead so nothing is lost
+func UnmarshalSectionLossless(config string, data []byte) (ConfigSection, error) {
+	var generic GenericSection
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	set := maps.Clone(map[string]List(generic.Options))
+	maps.DeleteFunc(set, func(_ string, value List) bool { return value == nil })
+
+	typed, err := UnmarshalSection(config, data)
+	if err != nil {
+		return generic, nil
+	}
+	options, err := UnmarshalSectionOptions(config, generic.Type, data)
+	if err != nil || !slices.Equal(MarkedDeleted(options), MarkedDeleted(generic.Options)) {
+		return generic, nil
+	}
+	values, err := OptionValues(typed)
+	if err != nil || !maps.EqualFunc(values, set, slices.Equal) {
+		return generic, nil
+	}
+	return typed, nil
+}
+
+// decodes raw options into the typed options registered for sectionType in config
+func UnmarshalSectionOptions(config, sectionType string, data []byte) (ConfigSectionOptions, error) {
+	options, err := unmarshalNew(NewSectionOptions(config, sectionType), data)
+	if err != nil {
+		return nil, err
+	}
+	return options.(ConfigSectionOptions), nil
+}
+
+// unmarshals data into a new value of the same type as zero and returns it, not a pointer to it
+func unmarshalNew(zero any, data []byte) (any, error) {
+	v := reflect.New(reflect.TypeOf(zero))
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rpcd
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "rpcd"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	Login = "login"
+	RPCD  = "rpcd"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{Login, RPCD}
+
+	uci.RegisterSection[LoginSection, LoginSectionOptions](Config, Login)
+	uci.RegisterSection[RPCDSection, RPCDSectionOptions](Config, RPCD)
+}
+
+// an account which can log into the ubus JSON-RPC API through session.login
+type LoginSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	LoginSectionOptions      `json:",inline"`
+}
+
+func (in *LoginSection) DeepCopyInto(out *LoginSection) {
+	*out = *in
+}
+
+type LoginSectionOptions struct {
+	// The password of the account, either a crypt() hash ("$1$..."), or "$p$<user>" to use the password of the
+	// given system user from /etc/shadow.
+	Password uci.Optional[string] `json:"password,omitzero"`
+	// Names of the ACL groups (see /usr/share/rpcd/acl.d) the account may read, "*" for all of them.
+	Read uci.Optional[uci.List] `json:"read,omitzero"`
+	// The username to log in with.
+	Username uci.Optional[string] `json:"username,omitzero"`
+	// Names of the ACL groups (see /usr/share/rpcd/acl.d) the account may write, "*" for all of them.
+	Write uci.Optional[uci.List] `json:"write,omitzero"`
+}
+
+func (LoginSectionOptions) IsConfigSectionOptions() {}
+
+type RPCDSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RPCDSectionOptions       `json:",inline"`
+}
+
+func (in *RPCDSection) DeepCopyInto(out *RPCDSection) {
+	*out = *in
+}
+
+type RPCDSectionOptions struct {
+	// Path of the ubus socket to connect to.
+	Socket uci.Optional[string] `json:"socket,omitzero"`
+	// Session timeout in seconds.
+	Timeout uci.Optional[uci.Int] `json:"timeout,omitzero"`
+}
+
+func (RPCDSectionOptions) IsConfigSectionOptions() {}
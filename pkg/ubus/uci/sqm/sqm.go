@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqm
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "sqm"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	Queue = "queue"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{Queue}
+
+	uci.RegisterSection[QueueSection, QueueSectionOptions](Config, Queue)
+}
+
+// smart queue management for a single device
+type QueueSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	QueueSectionOptions      `json:",inline"`
+}
+
+func (in *QueueSection) DeepCopyInto(out *QueueSection) {
+	*out = *in
+}
+
+type QueueSectionOptions struct {
+	// Whether to write debug output to /var/run/sqm.
+	DebugLogging uci.Optional[uci.Bool] `json:"debug_logging,omitzero"`
+	// Download (ingress) bandwidth in kbit/s, 0 disables ingress shaping.
+	Download uci.Optional[uci.Int] `json:"download,omitzero"`
+	// Whether to use ECN for egress traffic, "ECN" or "NOECN".
+	EgressECN uci.Optional[string] `json:"egress_ecn,omitzero"`
+	// Whether the queue is active.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Advanced options of the egress qdisc, e.g. "nat dual-srchost".
+	EQdiscOpts uci.Optional[string] `json:"eqdisc_opts,omitzero"`
+	// Whether to use ECN for ingress traffic, "ECN" or "NOECN".
+	IngressECN uci.Optional[string] `json:"ingress_ecn,omitzero"`
+	// The device to shape, e.g. "eth1" or "pppoe-wan".
+	Interface uci.Optional[string] `json:"interface,omitzero"`
+	// Advanced options of the ingress qdisc, e.g. "nat dual-dsthost ingress".
+	IQdiscOpts uci.Optional[string] `json:"iqdisc_opts,omitzero"`
+	// Link layer of the connection to account for: "none", "ethernet" or "atm".
+	LinkLayer uci.Optional[string] `json:"linklayer,omitzero"`
+	// Whether to use the advanced link layer options (tcMTU, tcTSIZE, tcMPU).
+	LinkLayerAdvanced uci.Optional[uci.Bool] `json:"linklayer_advanced,omitzero"`
+	// How the link layer is accounted for: "default", "cake", "htb_private" or "tc_stab".
+	LinkLayerAdaptationMechanism uci.Optional[string] `json:"linklayer_adaptation_mechanism,omitzero"`
+	// Per-packet overhead in bytes of the link layer.
+	Overhead uci.Optional[uci.Int] `json:"overhead,omitzero"`
+	// Queueing discipline to use, e.g. "cake" or "fq_codel".
+	Qdisc uci.Optional[string] `json:"qdisc,omitzero"`
+	// Whether to use the advanced qdisc options.
+	QdiscAdvanced uci.Optional[uci.Bool] `json:"qdisc_advanced,omitzero"`
+	// Whether to use iqdisc_opts and eqdisc_opts.
+	QdiscReallyReallyAdvanced uci.Optional[uci.Bool] `json:"qdisc_really_really_advanced,omitzero"`
+	// Name of the shaper script in /usr/lib/sqm, e.g. "piece_of_cake.qos".
+	Script uci.Optional[string] `json:"script,omitzero"`
+	// Whether to clear the DSCP marks of ingress packets.
+	SquashDSCP uci.Optional[uci.Bool] `json:"squash_dscp,omitzero"`
+	// Whether to ignore the DSCP marks of ingress packets.
+	SquashIngress uci.Optional[uci.Bool] `json:"squash_ingress,omitzero"`
+	// Minimal packet size in bytes, with linklayer_advanced.
+	TcMPU uci.Optional[uci.Int] `json:"tcMPU,omitzero"`
+	// Maximal packet size in bytes, with linklayer_advanced.
+	TcMTU uci.Optional[uci.Int] `json:"tcMTU,omitzero"`
+	// Number of entries of the size table, with linklayer_advanced.
+	TcTSIZE uci.Optional[uci.Int] `json:"tcTSIZE,omitzero"`
+	// Upload (egress) bandwidth in kbit/s, 0 disables egress shaping.
+	Upload uci.Optional[uci.Int] `json:"upload,omitzero"`
+	// Log verbosity, 0 (silent) to 10 (trace).
+	Verbosity uci.Optional[uci.Int] `json:"verbosity,omitzero"`
+}
+
+func (QueueSectionOptions) IsConfigSectionOptions() {}
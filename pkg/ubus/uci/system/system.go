@@ -25,18 +25,163 @@ const (
 	Config = "system"
 
 	// these are static values for the uci.StaticSectionOptions.Type field
+	Button     = "button"
+	GPIOSwitch = "gpio_switch"
+	LED        = "led"
+	RNGD       = "rngd"
 	System     = "system"
 	Timeserver = "timeserver"
 )
 
 var (
 	Sections []string
+	// the trigger-specific LEDSectionOptions which are valid for each trigger, keyed by trigger
+	// (or a pattern, see path.Match). options which are not listed for any trigger (e.g. sysfs)
+	// are valid for all of them
+	TriggerOptions map[string][]string
 )
 
 func init() {
-	Sections = []string{System, Timeserver}
+	Sections = []string{Button, GPIOSwitch, LED, RNGD, System, Timeserver}
+
+	TriggerOptions = map[string][]string{
+		"default-on": {},
+		"heartbeat":  {},
+		"netdev":     {"dev", "mode"},
+		"none":       {},
+		"oneshot":    {"delayoff", "delayon"},
+		"switch*":    {"mode", "port_mask", "speed_mask"},
+		"timer":      {"delayoff", "delayon"},
+		"usbport":    {"port"},
+	}
+
+	uci.RegisterSection[ButtonSection, ButtonSectionOptions](Config, Button)
+	uci.RegisterSection[GPIOSwitchSection, GPIOSwitchSectionOptions](Config, GPIOSwitch)
+	uci.RegisterSection[LEDSection, LEDSectionOptions](Config, LED)
+	uci.RegisterSection[RNGDSection, RNGDSectionOptions](Config, RNGD)
+	uci.RegisterSection[SystemSection, SystemSectionOptions](Config, System)
+	uci.RegisterSection[TimeserverSection, TimeserverSectionOptions](Config, Timeserver)
+}
+
+// runs a command when a button is pressed or released, handled by /etc/rc.button
+type ButtonSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	ButtonSectionOptions     `json:",inline"`
+}
+
+func (in *ButtonSection) DeepCopyInto(out *ButtonSection) {
+	*out = *in
+}
+
+type ButtonSectionOptions struct {
+	// The event to react to, "pressed" or "released".
+	Action uci.Optional[string] `json:"action,omitzero"`
+	// Name of the button as reported by the kernel, e.g. "reset" or "wps".
+	Button uci.Optional[string] `json:"button,omitzero"`
+	// Shell command to run.
+	Handler uci.Optional[string] `json:"handler,omitzero"`
+	// With action released, only run if the button was held for at most this many seconds.
+	Max uci.Optional[uci.Int] `json:"max,omitzero"`
+	// With action released, only run if the button was held for at least this many seconds.
+	Min uci.Optional[uci.Int] `json:"min,omitzero"`
+}
+
+func (ButtonSectionOptions) IsConfigSectionOptions() {}
+
+// sets a GPIO pin on boot, e.g. to power a USB port or a PoE output
+type GPIOSwitchSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	GPIOSwitchSectionOptions `json:",inline"`
+}
+
+func (in *GPIOSwitchSection) DeepCopyInto(out *GPIOSwitchSection) {
+	*out = *in
+}
+
+type GPIOSwitchSectionOptions struct {
+	// Number of the GPIO pin.
+	GPIOPin uci.Optional[uci.Int] `json:"gpio_pin,omitzero"`
+	// Human-readable name of the switch.
+	Name uci.Optional[string] `json:"name,omitzero"`
+	// Whether to drive the pin high.
+	Value uci.Optional[uci.Bool] `json:"value,omitzero"`
+}
+
+func (GPIOSwitchSectionOptions) IsConfigSectionOptions() {}
+
+type LEDSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	LEDSectionOptions        `json:",inline"`
+}
+
+func (in *LEDSection) DeepCopyInto(out *LEDSection) {
+	*out = *in
 }
 
+type LEDSectionOptions struct {
+	// Whether the LED is on when the trigger is "none".
+	Default uci.Optional[uci.Bool] `json:"default,omitzero"`
+	// Human-readable name of the LED.
+	Name uci.Optional[string] `json:"name,omitzero"`
+	// Name of the LED in /sys/class/leds, e.g. "green:wan".
+	Sysfs uci.Optional[string] `json:"sysfs,omitzero"`
+	// What the LED shows: "none", "default-on", "timer", "heartbeat", "netdev", "switch0", "usbport" etc.
+	// Selects which of the trigger-specific options below are valid, see TriggerOptions.
+	Trigger uci.Optional[string] `json:"trigger,omitzero"`
+
+	//
+	// trigger-specific options
+	//
+
+	// Milliseconds the LED is off per blink (timer, oneshot).
+	DelayOff uci.Optional[uci.Int] `json:"delayoff,omitzero"`
+	// Milliseconds the LED is on per blink (timer, oneshot).
+	DelayOn uci.Optional[uci.Int] `json:"delayon,omitzero"`
+	// The network device to monitor (netdev).
+	Dev uci.Optional[string] `json:"dev,omitzero"`
+	// Space-separated list of events to show: "link", "tx" and "rx" (netdev, switch).
+	Mode uci.Optional[string] `json:"mode,omitzero"`
+	// USB ports to monitor, e.g. "usb1-port1" (usbport).
+	Port uci.Optional[uci.List] `json:"port,omitzero"`
+	// Bit mask of the switch ports to monitor, e.g. "0x1e" (switch).
+	PortMask uci.Optional[string] `json:"port_mask,omitzero"`
+	// Bit mask of the link speeds to show, bit 0 = 10M, 1 = 100M, 2 = 1000M (switch).
+	SpeedMask uci.Optional[string] `json:"speed_mask,omitzero"`
+}
+
+func (LEDSectionOptions) IsConfigSectionOptions() {}
+
+// rejects trigger-specific options which are not valid for o.Trigger, see TriggerOptions and
+// uci.ValidateDependentOptions
+func (o LEDSectionOptions) Validate() error {
+	trigger, ok := o.Trigger.Get()
+	if !ok {
+		return nil
+	}
+	return uci.ValidateDependentOptions(o, "trigger", trigger, TriggerOptions)
+}
+
+// feeds the kernel entropy pool from a hardware random number generator
+type RNGDSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	RNGDSectionOptions       `json:",inline"`
+}
+
+func (in *RNGDSection) DeepCopyInto(out *RNGDSection) {
+	*out = *in
+}
+
+type RNGDSectionOptions struct {
+	// The random number generator to read from, e.g. "/dev/hwrng".
+	Device uci.Optional[string] `json:"device,omitzero"`
+	// Whether rngd is started.
+	Enabled uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	// Entropy pool level in bits below which rngd adds more.
+	FillWatermark uci.Optional[uci.Int] `json:"fill_watermark,omitzero"`
+}
+
+func (RNGDSectionOptions) IsConfigSectionOptions() {}
+
 type SystemSection struct {
 	uci.StaticSectionOptions `json:",inline"`
 	SystemSectionOptions     `json:",inline"`
@@ -48,53 +193,53 @@ func (in *SystemSection) DeepCopyInto(out *SystemSection) {
 
 type SystemSectionOptions struct {
 	// Kernel message buffer size.
-	Buffersize *uci.Int `json:"buffersize,omitempty"`
+	Buffersize uci.Optional[uci.Int] `json:"buffersize,omitzero"`
 	// Max log level for kernel messages to console (1–8).
-	Conloglevel *uci.Int `json:"conloglevel,omitempty"`
+	Conloglevel uci.Optional[uci.Int] `json:"conloglevel,omitzero"`
 	// Min level for cron messages (0–9+).
-	Cronloglevel *uci.Int `json:"cronloglevel,omitempty"`
+	Cronloglevel uci.Optional[uci.Int] `json:"cronloglevel,omitzero"`
 	// Short, single-line human-readable system description.
-	Description *string `json:"description,omitempty"`
+	Description uci.Optional[string] `json:"description,omitzero"`
 	// System hostname (avoid dots).
-	Hostname *string `json:"hostname,omitempty"`
+	Hostname uci.Optional[string] `json:"hostname,omitzero"`
 	// Same as conloglevel, but takes precedence.
-	Klogconloglevel *uci.Int `json:"klogconloglevel,omitempty"`
+	Klogconloglevel uci.Optional[uci.Int] `json:"klogconloglevel,omitzero"`
 	// Size of log buffer used by `logread`.
-	LogBufferSize *uci.Int `json:"log_buffer_size,omitempty"`
+	LogBufferSize uci.Optional[uci.Int] `json:"log_buffer_size,omitzero"`
 	// Path to log file (optional).
-	LogFile *string `json:"log_file,omitempty"`
+	LogFile uci.Optional[string] `json:"log_file,omitzero"`
 	// Hostname sent to remote syslog.
-	LogHostname *string `json:"log_hostname,omitempty"`
+	LogHostname uci.Optional[string] `json:"log_hostname,omitzero"`
 	// IP address of remote syslog server.
-	LogIP *string `json:"log_ip,omitempty"`
+	LogIP uci.Optional[string] `json:"log_ip,omitzero"`
 	// Port for remote syslog server (default: 514).
-	LogPort *uci.Int `json:"log_port,omitempty"`
+	LogPort uci.Optional[uci.Int] `json:"log_port,omitzero"`
 	// Prefix for network log messages.
-	LogPrefix *string `json:"log_prefix,omitempty"`
+	LogPrefix uci.Optional[string] `json:"log_prefix,omitzero"`
 	// Protocol: "tcp" or "udp" (default: udp).
-	LogProto *string `json:"log_proto,omitempty"`
+	LogProto uci.Optional[string] `json:"log_proto,omitzero"`
 	// Enable remote logging (default: true).
-	LogRemote *uci.Bool `json:"log_remote,omitempty"`
+	LogRemote uci.Optional[uci.Bool] `json:"log_remote,omitzero"`
 	// Log buffer size in KiB (default: 64).
-	LogSize *uci.Int `json:"log_size,omitempty"`
+	LogSize uci.Optional[uci.Int] `json:"log_size,omitzero"`
 	// Use `\0` instead of `\n` with TCP.
-	LogTrailerNull *uci.Bool `json:"log_trailer_null,omitempty"`
+	LogTrailerNull uci.Optional[uci.Bool] `json:"log_trailer_null,omitzero"`
 	// "circular" or "file".
-	LogType *string `json:"log_type,omitempty"`
+	LogType uci.Optional[string] `json:"log_type,omitzero"`
 	// Freeform multiline notes (e.g. location, inventory).
-	Notes *string `json:"notes,omitempty"`
+	Notes uci.Optional[string] `json:"notes,omitzero"`
 	// Require login on console access (default: false).
-	Ttylogin *uci.Bool `json:"ttylogin,omitempty"`
+	Ttylogin uci.Optional[uci.Bool] `json:"ttylogin,omitzero"`
 	// Path to urandom seed.
-	UrandomSeed *string `json:"urandom_seed,omitempty"`
+	UrandomSeed uci.Optional[string] `json:"urandom_seed,omitzero"`
 	// POSIX.1 timezone string (e.g. UTC).
-	Timezone *string `json:"timezone,omitempty"`
+	Timezone uci.Optional[string] `json:"timezone,omitzero"`
 	// IANA/Olson timezone string (e.g. Europe/London).
-	Zonename *string `json:"zonename,omitempty"`
+	Zonename uci.Optional[string] `json:"zonename,omitzero"`
 	// Compression algorithm for ZRAM (e.g. lzo, lz4, zstd).
-	ZramCompAlgo *string `json:"zram_comp_algo,omitempty"`
+	ZramCompAlgo uci.Optional[string] `json:"zram_comp_algo,omitzero"`
 	// ZRAM size in MB.
-	ZramSizeMB *uci.Int `json:"zram_size_mb,omitempty"`
+	ZramSizeMB uci.Optional[uci.Int] `json:"zram_size_mb,omitzero"`
 }
 
 func (SystemSectionOptions) IsConfigSectionOptions() {}
@@ -109,10 +254,10 @@ func (in *TimeserverSection) DeepCopyInto(out *TimeserverSection) {
 }
 
 type TimeserverSectionOptions struct {
-	Enabled      *uci.Bool `json:"enabled,omitempty"`
-	EnableServer *uci.Bool `json:"enable_server,omitempty"`
+	Enabled      uci.Optional[uci.Bool] `json:"enabled,omitzero"`
+	EnableServer uci.Optional[uci.Bool] `json:"enable_server,omitzero"`
 	// List of ntp servers to query
-	Server *uci.List `json:"server,omitempty"`
+	Server uci.Optional[uci.List] `json:"server,omitzero"`
 }
 
 func (TimeserverSectionOptions) IsConfigSectionOptions() {}
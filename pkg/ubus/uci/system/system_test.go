@@ -0,0 +1,47 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package system
+
+import (
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+func TestLEDValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		led   LEDSectionOptions
+		valid bool
+	}{
+		{"netdev", LEDSectionOptions{Trigger: uci.Some("netdev"), Dev: uci.Some("eth0"), Mode: uci.Some("link tx rx")}, true},
+		{"timer option on netdev", LEDSectionOptions{Trigger: uci.Some("netdev"), Dev: uci.Some("eth0"), DelayOn: uci.Some[uci.Int](500)}, false},
+		{"deleted timer option on netdev", LEDSectionOptions{Trigger: uci.Some("netdev"), DelayOn: uci.Delete[uci.Int]()}, true},
+		{"switch pattern", LEDSectionOptions{Trigger: uci.Some("switch0"), PortMask: uci.Some("0x1e")}, true},
+		{"netdev option on switch pattern", LEDSectionOptions{Trigger: uci.Some("switch0"), Dev: uci.Some("eth0")}, false},
+		{"unknown trigger", LEDSectionOptions{Trigger: uci.Some("phy0tpt"), Dev: uci.Some("eth0")}, true},
+		{"no trigger", LEDSectionOptions{DelayOn: uci.Some[uci.Int](500)}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := uci.Validate(test.led); (err == nil) != test.valid {
+				t.Errorf("expected valid: %v, got error: %v", test.valid, err)
+			}
+		})
+	}
+}
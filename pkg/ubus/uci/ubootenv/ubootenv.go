@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ubootenv
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "ubootenv"
+
+	// these are static values for the uci.StaticSectionOptions.Type field
+	UBootEnv = "ubootenv"
+	UBootSys = "ubootsys"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{UBootEnv, UBootSys}
+
+	uci.RegisterSection[UBootEnvSection, UBootEnvSectionOptions](Config, UBootEnv)
+	uci.RegisterSection[UBootSysSection, UBootEnvSectionOptions](Config, UBootSys)
+}
+
+// location of the U-Boot environment, used by fw_printenv and fw_setenv
+type UBootEnvSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	UBootEnvSectionOptions   `json:",inline"`
+}
+
+func (in *UBootEnvSection) DeepCopyInto(out *UBootEnvSection) {
+	*out = *in
+}
+
+type UBootEnvSectionOptions struct {
+	// The device holding the environment, e.g. "/dev/mtd1".
+	Dev uci.Optional[string] `json:"dev,omitzero"`
+	// Size of the environment, in hex.
+	EnvSize uci.Optional[string] `json:"envsize,omitzero"`
+	// Number of sectors the environment spans.
+	NumSecs uci.Optional[uci.Int] `json:"numsecs,omitzero"`
+	// Offset of the environment on the device, in hex.
+	Offset uci.Optional[string] `json:"offset,omitzero"`
+	// Size of an erase block of the device, in hex.
+	SecSize uci.Optional[string] `json:"secsize,omitzero"`
+}
+
+func (UBootEnvSectionOptions) IsConfigSectionOptions() {}
+
+// location of the secondary (system) U-Boot environment, same options as UBootEnvSection
+type UBootSysSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	UBootEnvSectionOptions   `json:",inline"`
+}
+
+func (in *UBootSysSection) DeepCopyInto(out *UBootSysSection) {
+	*out = *in
+}
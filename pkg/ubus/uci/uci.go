@@ -20,6 +20,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -29,12 +34,12 @@ const (
 	DHCP     = "dhcp"
 	Dropbear = "dropbear"
 	Firewall = "firewall"
-	//LuCI     = "luci"
-	Network = "network"
-	//RPCD     = "rpcd"
-	System = "system"
-	//UBootEnv = "ubootenv"
-	//UCITrack = "ucitrack"
+	LuCI     = "luci"
+	Network  = "network"
+	RPCD     = "rpcd"
+	System   = "system"
+	UBootEnv = "ubootenv"
+	UCITrack = "ucitrack"
 	UHTTPd   = "uhttpd"
 	Wireless = "wireless"
 )
@@ -44,7 +49,18 @@ var (
 )
 
 func init() {
-	Configs = []string{DHCP, Dropbear, Firewall, Network, System, UHTTPd, Wireless} //, LuCI, Network, RPCD, UBootEnv, UCITrack
+	Configs = []string{DHCP, Dropbear, Firewall, LuCI, Network, RPCD, System, UBootEnv, UCITrack, UHTTPd, Wireless}
+}
+
+// returns the first of patterns which matches the section type t (see path.Match), or t itself if
+// none of them do. used for section types which embed a name, e.g. "wireguard_*" in network
+func MatchSectionType(t string, patterns ...string) string {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, t); ok {
+			return pattern
+		}
+	}
+	return t
 }
 
 type ConfigSection interface {
@@ -83,13 +99,323 @@ type ConfigSectionOptions interface {
 	IsConfigSectionOptions()
 }
 
-type Bool bool
+// used for sections which have no typed model, e.g. sections of third-party packages. the
+// static fields and the options are flattened into a single object like in typed sections
+//
+// implements ConfigSection
+// implements json.Marshaler and json.Unmarshaler
+type GenericSection struct {
+	StaticSectionOptions
+	Options GenericSectionOptions
+}
+
+func (in *GenericSection) DeepCopyInto(out *GenericSection) {
+	*out = *in
+	out.Options = make(GenericSectionOptions, len(in.Options))
+	for name, value := range in.Options {
+		out.Options[name] = slices.Clone(value)
+	}
+}
+
+func (s GenericSection) MarshalJSON() ([]byte, error) {
+	out := make(map[string]any, len(s.Options)+4)
+	for name, value := range s.Options {
+		out[name] = value
+	}
+	out[".anonymous"] = s.Anonymous
+	out[".type"] = s.Type
+	out[".name"] = s.Name
+	out[".index"] = s.Index
+	return json.Marshal(out)
+}
+
+func (s *GenericSection) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &s.StaticSectionOptions); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &s.Options)
+}
+
+// the options of a GenericSection keyed by name. a nil value marks the option as deleted
+//
+// implements json.Unmarshaler
+type GenericSectionOptions map[string]List
+
+func (GenericSectionOptions) IsConfigSectionOptions() {}
+
+// static fields (.name, .type etc.) are skipped
+func (o *GenericSectionOptions) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*o = make(GenericSectionOptions, len(raw))
+	for name, value := range raw {
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		var list List
+		if err := json.Unmarshal(value, &list); err != nil {
+			return fmt.Errorf("option %s: %w", name, err)
+		}
+		(*o)[name] = list
+	}
+	return nil
+}
+
+// implemented by section options which can reject invalid combinations of options before they
+// are sent to the router, see Validate
+type Validator interface {
+	Validate() error
+}
+
+// validates o if it implements Validator, anything else is always valid
+func Validate(o ConfigSectionOptions) error {
+	if v, ok := o.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// rejects options of o which only belong to other values of a discriminating option, e.g. the
+// options of another proto on an interface. dependent maps each value (or path.Match pattern of
+// values) to the options it allows, every option listed for any value is dependent. nothing is
+// rejected if value has no entry in dependent, and deleting an option is always allowed
+func ValidateDependentOptions(o ConfigSectionOptions, option, value string, dependent map[string][]string) error {
+	valid, ok := dependent[value]
+	if !ok {
+		patterns := slices.Sorted(maps.Keys(dependent))
+		if valid, ok = dependent[MatchSectionType(value, patterns...)]; !ok {
+			return nil
+		}
+	}
+
+	names, err := OptionNames(o)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if slices.Contains(valid, name) {
+			continue
+		}
+		for _, options := range dependent {
+			if slices.Contains(options, name) {
+				return fmt.Errorf("option %s is not valid for %s %s", name, option, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// returns the names of all options which are set in o, i.e. every field which is neither unset
+// nor deleted.
+// static fields (.name, .type etc.) are never included
+func OptionNames(o ConfigSectionOptions) ([]string, error) {
+	var raw map[string]json.RawMessage
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(raw))
+	for name, value := range raw {
+		if strings.HasPrefix(name, ".") || string(value) == "null" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// returns the values of all options which are set in v, keyed by option name. v can be any typed
+// uci.ConfigSection or uci.ConfigSectionOptions. static fields (.name, .type etc.) are never included
+func OptionValues(v any) (map[string]List, error) {
+	var raw map[string]json.RawMessage
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]List, len(raw))
+	for name, value := range raw {
+		if strings.HasPrefix(name, ".") || string(value) == "null" {
+			continue
+		}
+		var list List
+		if err = json.Unmarshal(value, &list); err != nil {
+			return nil, fmt.Errorf("option %s: %w", name, err)
+		}
+		values[name] = list
+	}
 
-func BoolPtr(b bool) *Bool {
-	ptr := Bool(b)
-	return &ptr
+	return values, nil
 }
 
+// returns the names of the options which are set in current but not in desired, which are the
+// options that have to be deleted to go from current to desired. pass the result to
+// UCIDeleteOptions.Options
+func DeletedOptions[S ConfigSectionOptions](current, desired S) ([]string, error) {
+	currentNames, err := OptionNames(current)
+	if err != nil {
+		return nil, err
+	}
+	desiredNames, err := OptionNames(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, name := range currentNames {
+		if !slices.Contains(desiredNames, name) {
+			deleted = append(deleted, name)
+		}
+	}
+
+	return deleted, nil
+}
+
+type optionalState uint8
+
+const (
+	optionalUnset optionalState = iota
+	optionalSet
+	optionalDeleted
+)
+
+// tri-state value used for all fields of typed section options:
+//   - unset (the zero value): the option is left alone by `uci set`, always tag these with `omitzero`
+//   - set (see Some): the option is set to the value
+//   - deleted (see Delete): the option is removed, UCIInterface.Set turns it into a `uci delete`
+//
+// implements json.Marshaler and json.Unmarshaler
+type Optional[T any] struct {
+	value T
+	state optionalState
+}
+
+// returns an Optional which sets the option to v
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{value: v, state: optionalSet}
+}
+
+// returns an Optional which deletes the option
+func Delete[T any]() Optional[T] {
+	return Optional[T]{state: optionalDeleted}
+}
+
+// returns the value and whether it is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.state == optionalSet
+}
+
+// returns the value if it is set, otherwise the zero value of T
+func (o Optional[T]) Value() T {
+	return o.value
+}
+
+func (o Optional[T]) IsSet() bool {
+	return o.state == optionalSet
+}
+
+func (o Optional[T]) IsDeleted() bool {
+	return o.state == optionalDeleted
+}
+
+// reports whether the option is unset, used by the `omitzero` struct tag option
+func (o Optional[T]) IsZero() bool {
+	return o.state == optionalUnset
+}
+
+// marshals the value if set, otherwise null
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if o.state != optionalSet {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// null marks the option as deleted, anything else is unmarshaled into the value
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*o = Delete[T]()
+		return nil
+	}
+
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Some(v)
+	return nil
+}
+
+// implemented by Optional
+type deletable interface {
+	IsDeleted() bool
+}
+
+// returns the names of all options in o which are marked as deleted
+func MarkedDeleted(o ConfigSectionOptions) []string {
+	var names []string
+
+	if generic, ok := o.(GenericSectionOptions); ok {
+		for name, value := range generic {
+			if value == nil {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	v := reflect.ValueOf(o)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var walk func(v reflect.Value)
+	walk = func(v reflect.Value) {
+		for i := range v.NumField() {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && name == "" {
+				walk(v.Field(i))
+				continue
+			}
+			if d, ok := v.Field(i).Interface().(deletable); ok && d.IsDeleted() && name != "" && name != "-" {
+				names = append(names, name)
+			}
+		}
+	}
+	walk(v)
+	sort.Strings(names)
+
+	return names
+}
+
+type Bool bool
+
 // marshals the bool to a string value of "1" or "0"
 func (b Bool) MarshalJSON() ([]byte, error) {
 	if b {
@@ -116,11 +442,6 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 
 type Int int
 
-func IntPtr(i int) *Int {
-	ptr := Int(i)
-	return &ptr
-}
-
 // marshals int to a string
 func (i Int) MarshalJSON() ([]byte, error) {
 	str := strconv.Itoa(int(i))
@@ -0,0 +1,262 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uci
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestOptionNames(t *testing.T) {
+	opts := testOptionalSectionOptions{Enabled: Some[Bool](true), Name: Some("lan")}
+
+	names, err := OptionNames(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"enabled", "name"}
+	if !slices.Equal(names, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", names)
+	}
+}
+
+func TestDeletedOptions(t *testing.T) {
+	current := testOptionalSectionOptions{Enabled: Some[Bool](true), Name: Some("lan"), Network: Some(List{"lan"}), Port: Some[Int](22)}
+	desired := testOptionalSectionOptions{Name: Some("lan")}
+
+	deleted, err := DeletedOptions(current, desired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"enabled", "network", "port"}
+	if !slices.Equal(deleted, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", deleted)
+	}
+}
+
+type testOptionalSectionOptions struct {
+	Enabled Optional[Bool]   `json:"enabled,omitzero"`
+	Name    Optional[string] `json:"name,omitzero"`
+	Network Optional[List]   `json:"network,omitzero"`
+	Port    Optional[Int]    `json:"port,omitzero"`
+}
+
+func (testOptionalSectionOptions) IsConfigSectionOptions() {}
+
+func TestOptional(t *testing.T) {
+	opts := testOptionalSectionOptions{
+		Enabled: Some[Bool](true),
+		Network: Delete[List](),
+		Port:    Some[Int](22),
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"enabled":"1","network":null,"port":"22"}`
+	if string(data) != expected {
+		t.Error("\nexpected: ", expected, "\nactual: ", string(data))
+	}
+
+	var out testOptionalSectionOptions
+	if err = json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(opts, out) {
+		t.Error("\nexpected: ", opts, "\nactual: ", out)
+	}
+
+	if deleted := MarkedDeleted(opts); !slices.Equal(deleted, []string{"network"}) {
+		t.Error("\nexpected: [network]\nactual: ", deleted)
+	}
+	if names, _ := OptionNames(opts); !slices.Equal(names, []string{"enabled", "port"}) {
+		t.Error("\nexpected: [enabled port]\nactual: ", names)
+	}
+}
+
+func TestMatchSectionType(t *testing.T) {
+	for typ, expected := range map[string]string{
+		"wireguard_wg0": "wireguard_*",
+		"wireguard":     "wireguard",
+		"interface":     "interface",
+	} {
+		if actual := MatchSectionType(typ, "wireguard_*"); actual != expected {
+			t.Error("\nexpected: ", expected, "\nactual: ", actual)
+		}
+	}
+}
+
+func TestGenericSection(t *testing.T) {
+	data := `{".anonymous":false,".type":"queue",".name":"eth1",".index":2,"enabled":"1","script":"piece_of_cake.qos","qdisc_opts":["a","b"]}`
+
+	var section GenericSection
+	if err := json.Unmarshal([]byte(data), &section); err != nil {
+		t.Fatal(err)
+	}
+	expected := GenericSection{
+		StaticSectionOptions: StaticSectionOptions{Type: "queue", Name: "eth1", Index: 2},
+		Options:              GenericSectionOptions{"enabled": {"1"}, "script": {"piece_of_cake.qos"}, "qdisc_opts": {"a", "b"}},
+	}
+	if !reflect.DeepEqual(section, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", section)
+	}
+
+	out, err := json.Marshal(section)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var roundTrip GenericSection
+	if err = json.Unmarshal(out, &roundTrip); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(roundTrip, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", roundTrip)
+	}
+
+	opts := GenericSectionOptions{"enabled": {"0"}, "script": nil}
+	if deleted := MarkedDeleted(opts); !slices.Equal(deleted, []string{"script"}) {
+		t.Error("\nexpected: [script]\nactual: ", deleted)
+	}
+	if names, _ := OptionNames(opts); !slices.Equal(names, []string{"enabled"}) {
+		t.Error("\nexpected: [enabled]\nactual: ", names)
+	}
+}
+
+type testSection struct {
+	StaticSectionOptions       `json:",inline"`
+	testOptionalSectionOptions `json:",inline"`
+}
+
+func TestRegistry(t *testing.T) {
+	RegisterSection[testSection, testOptionalSectionOptions]("test", "peer_*")
+	t.Cleanup(func() { Unregister("test", "peer_*") })
+
+	section, err := UnmarshalSection("test", []byte(`{".type":"peer_wg0",".name":"cfg1","port":"22"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := testSection{
+		StaticSectionOptions:       StaticSectionOptions{Type: "peer_wg0", Name: "cfg1"},
+		testOptionalSectionOptions: testOptionalSectionOptions{Port: Some[Int](22)},
+	}
+	if !reflect.DeepEqual(section, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", section)
+	}
+
+	// same type in another config has no model
+	section, err = UnmarshalSection("other", []byte(`{".type":"peer_wg0",".name":"cfg1","port":"22"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := section.(GenericSection); !ok {
+		t.Errorf("expected GenericSection, got %T", section)
+	}
+
+	options, err := UnmarshalSectionOptions("test", "peer_wg1", []byte(`{"enabled":"1"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := options.(testOptionalSectionOptions); !ok {
+		t.Errorf("expected testOptionalSectionOptions, got %T", options)
+	}
+
+	if sectionType, ok := SectionTypeOf[testSection]("test"); !ok || sectionType != "peer_*" || !IsSectionTypePattern(sectionType) {
+		t.Error("\nexpected: peer_*\nactual: ", sectionType)
+	}
+	if _, ok := SectionTypeOf[testSection]("other"); ok {
+		t.Error("testSection is not registered for config other")
+	}
+
+	// the first type in sorted order, whatever order the map is iterated in
+	RegisterSection[testSection, testOptionalSectionOptions]("test", "zpeer")
+	RegisterSection[testSection, testOptionalSectionOptions]("test", "apeer")
+	for range 10 {
+		if sectionType, _ := SectionTypeOf[testSection]("test"); sectionType != "apeer" {
+			t.Fatal("\nexpected: apeer\nactual: ", sectionType)
+		}
+	}
+	Unregister("test", "zpeer")
+	Unregister("test", "apeer")
+
+	Unregister("test", "peer_*")
+	if _, ok := SectionTypeOf[testSection]("test"); ok {
+		t.Error("testSection is still registered after Unregister")
+	}
+	if section, _ = UnmarshalSection("test", []byte(`{".type":"peer_wg0"}`)); reflect.TypeOf(section) != reflect.TypeFor[GenericSection]() {
+		t.Errorf("expected GenericSection after Unregister, got %T", section)
+	}
+}
+
+func TestUnmarshalSectionLossless(t *testing.T) {
+	RegisterSection[testSection, testOptionalSectionOptions]("test", "peer_*")
+	t.Cleanup(func() { Unregister("test", "peer_*") })
+
+	section, err := UnmarshalSectionLossless("test", []byte(`{".type":"peer_wg0",".name":"cfg1","port":"22"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := section.(testSection); !ok {
+		t.Errorf("expected testSection, got %T", section)
+	}
+	section, err = UnmarshalSectionLossless("test", []byte(`{".type":"peer_wg0",".name":"cfg1","port":null}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := section.(testSection); !ok || !s.Port.IsDeleted() {
+		t.Errorf("expected testSection with port deleted, got %#v", section)
+	}
+
+	// unknown options, also ones marked as deleted, and values the model cannot parse are kept
+	for _, data := range []string{
+		`{".type":"peer_wg0",".name":"cfg1","port":"22","unknown":"1"}`,
+		`{".type":"peer_wg0",".name":"cfg1","port":"22","unknown":null}`,
+		`{".type":"peer_wg0",".name":"cfg1","enabled":"yes"}`,
+	} {
+		section, err = UnmarshalSectionLossless("test", []byte(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := section.(GenericSection); !ok {
+			t.Errorf("%s: expected GenericSection, got %T", data, section)
+		}
+	}
+}
+
+func TestValidateDependentOptions(t *testing.T) {
+	dependent := map[string][]string{
+		"static": {"port"},
+		"peer_*": {"network"},
+		"dhcp":   {},
+	}
+	opts := testOptionalSectionOptions{Port: Some[Int](22)}
+
+	if err := ValidateDependentOptions(opts, "proto", "static", dependent); err != nil {
+		t.Error(err)
+	}
+	if err := ValidateDependentOptions(opts, "proto", "dhcp", dependent); err == nil || err.Error() != "option port is not valid for proto dhcp" {
+		t.Error("expected an error for port on dhcp, got", err)
+	}
+	if err := ValidateDependentOptions(opts, "proto", "peer_wg0", dependent); err == nil {
+		t.Error("expected an error for port on peer_wg0")
+	}
+	if err := ValidateDependentOptions(opts, "proto", "unknown", dependent); err != nil {
+		t.Error(err)
+	}
+}
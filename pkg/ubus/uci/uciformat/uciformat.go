@@ -0,0 +1,590 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// reads and writes the native UCI text format of the files in /etc/config, e.g.
+//
+//	config zone 'wan'
+//		option name 'wan'
+//		list network 'wan'
+//		list network 'wan6'
+//
+// without a router, and converts them to the typed sections of pkg/ubus/uci and the JSON rpcd returns
+package uciformat
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+// a parsed config file. comments are the full lines starting with '#', including it
+type File struct {
+	// the name given by a `package` statement, usually empty since it is implied by the file name
+	Package string
+	// comments before the `package` statement
+	Comments []string
+	Sections []Section
+	// comments after the last option
+	Trailer []string
+}
+
+type Section struct {
+	Type string
+	// for anonymous sections this is the name libuci generates for them, e.g. cfg03dc81, so it
+	// matches the one rpcd returns as long as the file is unchanged
+	Name      string
+	Anonymous bool
+	Options   []Option
+	// comment lines before the `config` line and the comment at its end
+	Comments []string
+	Comment  string
+}
+
+type Option struct {
+	Name string
+	// always has one value for options and at least one for lists
+	Values []string
+	List   bool
+	// comment lines before the (first) `option` or `list` line and the comment at its end
+	Comments []string
+	Comment  string
+}
+
+// returns the option with the given name
+func (s Section) Option(name string) (Option, bool) {
+	for _, o := range s.Options {
+		if o.Name == name {
+			return o, true
+		}
+	}
+	return Option{}, false
+}
+
+// parses the UCI text format the same way libuci does. quoting, escapes, line continuations and
+// `;` between statements are resolved, repeated `option`s overwrite each other and repeated `list`s
+// append
+func Parse(r io.Reader) (*File, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{}
+	l := lexer{data: data, line: 1}
+	var comments []string
+	var section *Section
+	for {
+		words, comment, line, err := l.next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if len(words) == 0 {
+			if comment != "" {
+				comments = append(comments, comment)
+			}
+			continue
+		}
+
+		switch words[0] {
+		case "package":
+			if len(words) != 2 {
+				return nil, fmt.Errorf("line %d: expected `package <name>`", line)
+			} else if len(f.Sections) > 0 {
+				return nil, fmt.Errorf("line %d: package has to come before any config", line)
+			}
+			f.Package = words[1]
+			f.Comments = append(f.Comments, comments...)
+		case "config":
+			if len(words) < 2 || len(words) > 3 {
+				return nil, fmt.Errorf("line %d: expected `config <type> [<name>]`", line)
+			}
+			s := Section{Type: words[1], Anonymous: true, Comments: comments, Comment: comment}
+			if len(words) == 3 && words[2] != "" {
+				s.Name, s.Anonymous = words[2], false
+			}
+			// libuci merges sections which are declared twice, keeping the position of the first
+			// declaration and the type of the last one
+			if i := f.index(s.Name); !s.Anonymous && i >= 0 {
+				section = &f.Sections[i]
+				section.Type = s.Type
+				break
+			}
+			f.Sections = append(f.Sections, s)
+			section = &f.Sections[len(f.Sections)-1]
+		case "option", "list":
+			if section == nil {
+				return nil, fmt.Errorf("line %d: %s outside of a config", line, words[0])
+			} else if len(words) != 3 {
+				return nil, fmt.Errorf("line %d: expected `%s <name> <value>`", line, words[0])
+			}
+			section.set(Option{Name: words[1], Values: []string{words[2]}, List: words[0] == "list", Comments: comments, Comment: comment})
+		default:
+			return nil, fmt.Errorf("line %d: unknown statement %q", line, words[0])
+		}
+		comments = nil
+	}
+
+	f.Trailer = comments
+	f.nameAnonymous()
+	return f, nil
+}
+
+// adds o to the section, overwriting an option or appending to a list of the same name. like in
+// libuci an option turns into a list if list values are added to it
+func (s *Section) set(o Option) {
+	i := slices.IndexFunc(s.Options, func(existing Option) bool { return existing.Name == o.Name })
+	switch {
+	case i < 0:
+		s.Options = append(s.Options, o)
+	case o.List:
+		s.Options[i].Values = append(s.Options[i].Values, o.Values...)
+		s.Options[i].List = true
+	default:
+		s.Options[i].Values = o.Values
+		s.Options[i].List = false
+	}
+}
+
+func (f *File) index(name string) int {
+	return slices.IndexFunc(f.Sections, func(s Section) bool { return !s.Anonymous && s.Name == name })
+}
+
+// names anonymous sections like libuci does when it loads a file: "cfg", a counter of all
+// sections, named ones included, and a hash of the section type. e.g. the anonymous device after
+// the loopback interface and globals of the default network config is cfg030f15. the options are
+// not part of the hash since libuci names a section before it reads its options
+func (f *File) nameAnonymous() {
+	for i := range f.Sections {
+		s := &f.Sections[i]
+		if s.Anonymous {
+			s.Name = fmt.Sprintf("cfg%02x%04x", i+1, djbhash(5381, s.Type)%(1<<16))
+		}
+	}
+}
+
+func djbhash(hash uint32, s string) uint32 {
+	for i := 0; i < len(s); i++ {
+		hash = (hash << 5) + hash + uint32(s[i])
+	}
+	return hash & 0x7fffffff
+}
+
+// splits the input into statements
+type lexer struct {
+	data []byte
+	pos  int
+	line int
+}
+
+// returns the words of the next statement, the comment at its end and the line it starts on.
+// blank and comment-only lines have no words. a statement ends at the end of its line or at a `;`
+// which is followed by another statement
+func (l *lexer) next() (words []string, comment string, line int, err error) {
+	if l.pos >= len(l.data) {
+		return nil, "", l.line, io.EOF
+	}
+	line = l.line
+
+	for l.pos < len(l.data) {
+		switch c := l.data[l.pos]; {
+		case c == '\n':
+			l.pos++
+			l.line++
+			return words, comment, line, nil
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case c == ';':
+			l.pos++
+			for l.pos < len(l.data) && (l.data[l.pos] == ' ' || l.data[l.pos] == '\t' || l.data[l.pos] == '\r') {
+				l.pos++
+			}
+			// the rest of the line is only a comment of this statement otherwise
+			if len(words) > 0 && l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '#' {
+				return words, comment, line, nil
+			}
+		case c == '#':
+			end := bytes.IndexByte(l.data[l.pos:], '\n')
+			if end < 0 {
+				end = len(l.data) - l.pos
+			}
+			comment = strings.TrimRight(string(l.data[l.pos:l.pos+end]), " \t\r")
+			l.pos += end
+		default:
+			word, err := l.word()
+			if err != nil {
+				return nil, "", line, err
+			}
+			words = append(words, word)
+		}
+	}
+
+	return words, comment, line, nil
+}
+
+// reads a word made of any mix of unquoted, 'single' and "double" quoted parts. like in libuci an
+// unquoted `#` starts a comment and an unquoted `;` ends the statement, both also end the word
+func (l *lexer) word() (string, error) {
+	var b strings.Builder
+	start := l.line
+
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		switch c {
+		case ' ', '\t', '\r', '\n', '#', ';':
+			return b.String(), nil
+		case '\'':
+			end := bytes.IndexByte(l.data[l.pos+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("line %d: unterminated '", start)
+			}
+			part := l.data[l.pos+1 : l.pos+1+end]
+			l.line += bytes.Count(part, []byte("\n"))
+			b.Write(part)
+			l.pos += end + 2
+		case '"':
+			l.pos++
+			for {
+				if l.pos >= len(l.data) {
+					return "", fmt.Errorf("line %d: unterminated \"", start)
+				}
+				c = l.data[l.pos]
+				if c == '"' {
+					l.pos++
+					break
+				}
+				if c == '\\' && l.pos+1 < len(l.data) {
+					l.pos++
+					c = l.data[l.pos]
+				}
+				if c == '\n' {
+					l.line++
+				}
+				b.WriteByte(c)
+				l.pos++
+			}
+		case '\\':
+			l.pos++
+			if l.pos < len(l.data) {
+				if l.data[l.pos] == '\n' {
+					// line continuation
+					l.line++
+				} else {
+					b.WriteByte(l.data[l.pos])
+				}
+				l.pos++
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+
+	return b.String(), nil
+}
+
+// writes f in the format `uci commit` uses: one tab of indentation, values in single quotes and a
+// blank line between sections. comments are written back where they were
+func (f *File) WriteTo(w io.Writer) (int64, error) {
+	var b bytes.Buffer
+
+	for _, c := range f.Comments {
+		b.WriteString(c + "\n")
+	}
+	if f.Package != "" {
+		b.WriteString("package " + escape(f.Package) + "\n")
+	}
+	for _, s := range f.Sections {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		for _, c := range s.Comments {
+			b.WriteString(c + "\n")
+		}
+		b.WriteString("config " + escape(s.Type))
+		if !s.Anonymous {
+			b.WriteString(" " + quote(s.Name))
+		}
+		writeComment(&b, s.Comment)
+
+		for _, o := range s.Options {
+			for _, c := range o.Comments {
+				b.WriteString("\t" + c + "\n")
+			}
+			keyword := "option"
+			if o.List {
+				keyword = "list"
+			}
+			for i, v := range o.Values {
+				b.WriteString("\t" + keyword + " " + escape(o.Name) + " " + quote(v))
+				if i == 0 {
+					writeComment(&b, o.Comment)
+				} else {
+					b.WriteString("\n")
+				}
+			}
+		}
+	}
+	if len(f.Trailer) > 0 {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		for _, c := range f.Trailer {
+			b.WriteString(c + "\n")
+		}
+	}
+
+	n, err := w.Write(b.Bytes())
+	return int64(n), err
+}
+
+// returns f in the UCI text format, see WriteTo
+func (f *File) String() string {
+	var b strings.Builder
+	f.WriteTo(&b)
+	return b.String()
+}
+
+func writeComment(b *bytes.Buffer, comment string) {
+	if comment != "" {
+		b.WriteString(" " + comment)
+	}
+	b.WriteString("\n")
+}
+
+// escapes single quotes the way libuci does, by closing the quote, escaping it and reopening it
+func escape(s string) string {
+	return strings.ReplaceAll(s, "'", `'\''`)
+}
+
+func quote(s string) string {
+	return "'" + escape(s) + "'"
+}
+
+// returns the sections the way rpcd returns a whole config in the "values" of a `uci get`, i.e. an
+// object keyed by section name with the static fields (.name, .type etc.) and options in order.
+// options are strings and lists are arrays
+func (f *File) MarshalValues() ([]byte, error) {
+	var b bytes.Buffer
+
+	b.WriteByte('{')
+	for i, s := range f.Sections {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		name, err := json.Marshal(s.Name)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(name)
+		b.WriteByte(':')
+		section, err := s.marshalJSON(i)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(section)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+func (s Section) marshalJSON(index int) ([]byte, error) {
+	var b bytes.Buffer
+
+	static, err := json.Marshal(uci.StaticSectionOptions{Anonymous: s.Anonymous, Type: s.Type, Name: s.Name, Index: index})
+	if err != nil {
+		return nil, err
+	}
+	b.Write(static[:len(static)-1]) // without the closing brace
+
+	for _, o := range s.Options {
+		var value any = o.Values
+		if !o.List {
+			value = o.Values[0]
+		}
+		name, err := json.Marshal(o.Name)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteByte(',')
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(data)
+	}
+	b.WriteByte('}')
+
+	return b.Bytes(), nil
+}
+
+// builds a file from the "values" of a `uci get` of a whole config, see MarshalValues. sections
+// are ordered by their .index and options keep the order they have in data
+func UnmarshalValues(data []byte) (*File, error) {
+	names, raw, err := orderedObject(data)
+	if err != nil {
+		return nil, err
+	}
+
+	type indexed struct {
+		Section
+		index int
+	}
+	sections := make([]indexed, 0, len(names))
+	for _, name := range names {
+		keys, values, err := orderedObject(raw[name])
+		if err != nil {
+			return nil, fmt.Errorf("section %s: %w", name, err)
+		}
+		var static uci.StaticSectionOptions
+		if err = json.Unmarshal(raw[name], &static); err != nil {
+			return nil, fmt.Errorf("section %s: %w", name, err)
+		}
+
+		s := indexed{Section: Section{Type: static.Type, Name: name, Anonymous: static.Anonymous}, index: static.Index}
+		for _, key := range keys {
+			if strings.HasPrefix(key, ".") {
+				continue
+			}
+			o := Option{Name: key}
+			if err = json.Unmarshal(values[key], &o.Values); err == nil {
+				o.List = true
+			} else {
+				o.Values = make([]string, 1)
+				if err = json.Unmarshal(values[key], &o.Values[0]); err != nil {
+					return nil, fmt.Errorf("section %s: option %s: %w", name, key, err)
+				}
+			}
+			s.Options = append(s.Options, o)
+		}
+		sections = append(sections, s)
+	}
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].index < sections[j].index })
+
+	f := &File{}
+	for _, s := range sections {
+		f.Sections = append(f.Sections, s.Section)
+	}
+	return f, nil
+}
+
+// returns the keys of the JSON object in data in order, and their values
+func orderedObject(data []byte) (keys []string, values map[string]json.RawMessage, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return nil, nil, err
+	} else if t != json.Delim('{') {
+		return nil, nil, errors.New("not a JSON object")
+	}
+
+	values = make(map[string]json.RawMessage)
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key := t.(string)
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		values[key] = value
+	}
+
+	return keys, values, nil
+}
+
+// decodes the sections into the models registered for config (or f.Package if config is empty),
+// see uci.UnmarshalSectionLossless
+func (f *File) ConfigSections(config string) ([]uci.ConfigSection, error) {
+	if config == "" {
+		config = f.Package
+	}
+	if config == "" {
+		return nil, errors.New("no config name given and the file has no package statement")
+	}
+
+	sections := make([]uci.ConfigSection, 0, len(f.Sections))
+	for i, s := range f.Sections {
+		data, err := s.marshalJSON(i)
+		if err != nil {
+			return nil, err
+		}
+		section, err := uci.UnmarshalSectionLossless(config, data)
+		if err != nil {
+			return nil, fmt.Errorf("section %s: %w", s.Name, err)
+		}
+		sections = append(sections, section)
+	}
+
+	return sections, nil
+}
+
+// replaces the sections of f, e.g. with changed sections returned by ConfigSections. sections
+// which already exist (same name and type) keep their comments and the order of their options,
+// new options are appended sorted by name. options which were lists stay lists even with a
+// single value, new ones only become lists if they have several values
+func (f *File) SetConfigSections(sections []uci.ConfigSection) error {
+	var out []Section
+	for _, cs := range sections {
+		values, err := uci.OptionValues(cs)
+		if err != nil {
+			return fmt.Errorf("section %s: %w", cs.GetName(), err)
+		}
+
+		s := Section{Type: cs.GetType(), Name: cs.GetName(), Anonymous: cs.IsAnonymous() || cs.GetName() == ""}
+		var existing Section
+		if i := slices.IndexFunc(f.Sections, func(e Section) bool { return e.Name == s.Name && e.Type == s.Type }); i >= 0 && s.Name != "" {
+			existing = f.Sections[i]
+			s.Comments, s.Comment = existing.Comments, existing.Comment
+		}
+
+		for _, o := range existing.Options {
+			if v, ok := values[o.Name]; ok {
+				o.Values = v
+				o.List = o.List || len(v) > 1
+				s.Options = append(s.Options, o)
+				delete(values, o.Name)
+			}
+		}
+		names := make([]string, 0, len(values))
+		for name := range values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			s.Options = append(s.Options, Option{Name: name, Values: values[name], List: len(values[name]) > 1})
+		}
+
+		out = append(out, s)
+	}
+
+	f.Sections = out
+	f.nameAnonymous()
+	return nil
+}
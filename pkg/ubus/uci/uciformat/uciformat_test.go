@@ -0,0 +1,276 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uciformat
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci/firewall"
+)
+
+const firewallFile = `# managed by hand
+config defaults
+	option syn_flood '1'
+	option input 'REJECT'
+
+# the local network
+config zone 'lan' # trusted
+	option name 'lan'
+	list network 'lan'
+	option input 'ACCEPT'
+
+config zone
+	option name 'wan'
+	list network 'wan'
+	list network 'wan6'
+	option custom 'it'\''s kept'
+
+# end
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(firewallFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := []string{}
+	for _, s := range f.Sections {
+		names = append(names, s.Name)
+	}
+	expected := []string{"cfg01e63d", "lan", "cfg03dc81"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", names)
+	}
+
+	lan := f.Sections[1]
+	if lan.Anonymous || lan.Comment != "# trusted" || !reflect.DeepEqual(lan.Comments, []string{"# the local network"}) {
+		t.Errorf("unexpected lan section: %+v", lan)
+	}
+	network, _ := f.Sections[2].Option("network")
+	if !network.List || !reflect.DeepEqual(network.Values, []string{"wan", "wan6"}) {
+		t.Errorf("unexpected network option: %+v", network)
+	}
+	custom, _ := f.Sections[2].Option("custom")
+	if custom.Values[0] != "it's kept" {
+		t.Errorf("expected unescaped value, got %q", custom.Values[0])
+	}
+	if !reflect.DeepEqual(f.Trailer, []string{"# end"}) {
+		t.Errorf("unexpected trailer: %q", f.Trailer)
+	}
+}
+
+func TestAnonymousNames(t *testing.T) {
+	// the start of the default network config, the counter includes the named sections
+	f, err := Parse(strings.NewReader("config interface 'loopback'\n" +
+		"config globals 'globals'\n" +
+		"config device\n\toption name 'br-lan'\n" +
+		"config interface 'lan'\n" +
+		"config device\n\toption name 'eth1'\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Sections[2].Name != "cfg030f15" || f.Sections[4].Name != "cfg050f15" {
+		t.Errorf("unexpected names: %s, %s", f.Sections[2].Name, f.Sections[4].Name)
+	}
+}
+
+func TestParseSyntax(t *testing.T) {
+	input := "package network\n" +
+		"config interface \"l\"'an'\n" +
+		"\toption ipaddr 192.168.1.1\t# unquoted\n" +
+		"\toption ipaddr '10.0.0.1'\n" +
+		"\toption dns \"1.1.1.1 \\\"x\\\"\"\n" +
+		"\tlist dns 8.8.8.8\n" +
+		"\toption proto \\\nstatic\n"
+
+	f, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Package != "network" || len(f.Sections) != 1 || f.Sections[0].Name != "lan" {
+		t.Fatalf("unexpected file: %+v", f)
+	}
+	expected := []Option{
+		{Name: "ipaddr", Values: []string{"10.0.0.1"}, Comment: "# unquoted"},
+		{Name: "dns", Values: []string{`1.1.1.1 "x"`, "8.8.8.8"}, List: true},
+		{Name: "proto", Values: []string{"static"}},
+	}
+	if !reflect.DeepEqual(f.Sections[0].Options, expected) {
+		t.Error("\nexpected: ", expected, "\nactual: ", f.Sections[0].Options)
+	}
+
+	for _, input := range []string{
+		"option name 'lan'\n",
+		"config zone\n\toption name 'lan\n",
+		"config zone\n\toption name\n",
+		"config zone\nfoo bar\n",
+	} {
+		if _, err := Parse(strings.NewReader(input)); err == nil {
+			t.Errorf("expected an error for %q", input)
+		}
+	}
+}
+
+// inputs libuci accepts which look invalid at first sight
+func TestParseLikeLibuci(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "comment after an unquoted word",
+			input:    "config zone 'lan'\n\toption name lan#trusted\n",
+			expected: "config zone 'lan'\n\toption name 'lan' #trusted\n",
+		},
+		{
+			name:     "quoted #",
+			input:    "config zone 'lan'\n\toption name \"lan#1\"\n",
+			expected: "config zone 'lan'\n\toption name 'lan#1'\n",
+		},
+		{
+			name:     "statements separated by ;",
+			input:    "config zone 'lan'; option name lan;list network lan\n",
+			expected: "config zone 'lan'\n\toption name 'lan'\n\tlist network 'lan'\n",
+		},
+		{
+			name:     "; at the end of a line",
+			input:    "config zone 'lan';\n\toption name lan ; # trusted\n",
+			expected: "config zone 'lan'\n\toption name 'lan' # trusted\n",
+		},
+		{
+			name:     "quoted ;",
+			input:    "config zone 'lan'\n\toption name 'a;b'\n",
+			expected: "config zone 'lan'\n\toption name 'a;b'\n",
+		},
+		{
+			name: "section redeclared with another type",
+			input: "config zone 'lan'\n\toption name lan\n" +
+				"config interface 'wan'\n" +
+				"config rule 'lan'\n\toption name guest\n\toption target ACCEPT\n",
+			expected: "config rule 'lan'\n\toption name 'guest'\n\toption target 'ACCEPT'\n\n" +
+				"config interface 'wan'\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := Parse(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := f.String(); actual != test.expected {
+				t.Errorf("\nexpected:\n%s\nactual:\n%s", test.expected, actual)
+			}
+		})
+	}
+
+	// the merged declaration is not counted for the names of anonymous sections
+	f, err := Parse(strings.NewReader("config zone 'lan'\nconfig rule 'lan'\nconfig zone\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Sections) != 2 || f.Sections[1].Name != "cfg02dc81" {
+		t.Errorf("unexpected sections: %+v", f.Sections)
+	}
+}
+
+func TestWriteTo(t *testing.T) {
+	f, err := Parse(strings.NewReader(firewallFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := f.String(); actual != firewallFile {
+		t.Error("\nexpected:\n", firewallFile, "\nactual:\n", actual)
+	}
+}
+
+func TestValues(t *testing.T) {
+	f, err := Parse(strings.NewReader(firewallFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := f.MarshalValues()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `{"cfg01e63d":{".anonymous":true,".type":"defaults",".name":"cfg01e63d",".index":0,"syn_flood":"1"`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+
+	// rpcd does not keep the sections in order, only their .index does
+	var values map[string]json.RawMessage
+	if err = json.Unmarshal(data, &values); err != nil {
+		t.Fatal(err)
+	}
+	shuffled, _ := json.Marshal(values)
+	g, err := UnmarshalValues(shuffled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range f.Sections {
+		f.Sections[i].Comments, f.Sections[i].Comment = nil, ""
+	}
+	f.Trailer = nil
+	if !reflect.DeepEqual(f.Sections, g.Sections) {
+		t.Error("\nexpected: ", f.Sections, "\nactual: ", g.Sections)
+	}
+}
+
+func TestConfigSections(t *testing.T) {
+	f, err := Parse(strings.NewReader(firewallFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.ConfigSections(""); err == nil {
+		t.Error("expected an error without a config name")
+	}
+	sections, err := f.ConfigSections(firewall.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, ok := sections[0].(firewall.DefaultsSection)
+	if !ok {
+		t.Fatalf("expected a DefaultsSection, got %T", sections[0])
+	} else if defaults.Input.Value() != "REJECT" {
+		t.Errorf("unexpected input: %v", defaults.Input)
+	}
+	// the custom option has no field in ZoneSection so the section is kept generic
+	if _, ok = sections[2].(uci.GenericSection); !ok {
+		t.Fatalf("expected a GenericSection, got %T", sections[2])
+	}
+
+	lan := sections[1].(firewall.ZoneSection)
+	lan.Input = uci.Some("REJECT")
+	lan.Network = uci.Some(uci.List{"lan"})
+	lan.Masq = uci.Some(uci.Bool(true))
+	sections[1] = lan
+	if err = f.SetConfigSections(sections); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Replace(firewallFile, "\toption input 'ACCEPT'\n", "\toption input 'REJECT'\n\toption masq '1'\n", 1)
+	if actual := f.String(); actual != expected {
+		t.Error("\nexpected:\n", expected, "\nactual:\n", actual)
+	}
+}
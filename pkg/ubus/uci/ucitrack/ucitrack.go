@@ -0,0 +1,62 @@
+/*
+Copyright 2025 Daimonas Labs.
+
+Licensed under the GNU General Public License, Version 3 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.gnu.org/licenses/gpl-3.0.en.html
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ucitrack
+
+import (
+	"github.com/daimonaslabs/go-ubus-rpc/pkg/ubus/uci"
+)
+
+const (
+	// the name of this config
+	Config = "ucitrack"
+
+	// every section of ucitrack is named after and has the type of the config it tracks (e.g. "network"),
+	// so they all share TrackSection. pattern for the uci.StaticSectionOptions.Type field
+	Track = "*"
+)
+
+var (
+	Sections []string
+)
+
+func init() {
+	Sections = []string{Track}
+
+	uci.RegisterSection[TrackSection, TrackSectionOptions](Config, Track)
+}
+
+// tells LuCI which init scripts to run and which other configs to reload when the config named
+// by the section type is applied
+type TrackSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	TrackSectionOptions      `json:",inline"`
+}
+
+func (in *TrackSection) DeepCopyInto(out *TrackSection) {
+	*out = *in
+}
+
+type TrackSectionOptions struct {
+	// Names of the other configs which are reloaded along with this one.
+	Affects uci.Optional[uci.List] `json:"affects,omitzero"`
+	// Command to run when the config changes.
+	Exec uci.Optional[string] `json:"exec,omitzero"`
+	// Name of the init script (/etc/init.d) to reload when the config changes.
+	Init uci.Optional[string] `json:"init,omitzero"`
+}
+
+func (TrackSectionOptions) IsConfigSectionOptions() {}
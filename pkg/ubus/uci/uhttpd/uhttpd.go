@@ -35,6 +35,9 @@ var (
 
 func init() {
 	Sections = []string{Cert, UHTTPd}
+
+	uci.RegisterSection[CertSection, CertSectionOptions](Config, Cert)
+	uci.RegisterSection[UHTTPdSection, UHTTPdSectionOptions](Config, UHTTPd)
 }
 
 type CertSection struct {
@@ -48,21 +51,21 @@ func (in *CertSection) DeepCopyInto(out *CertSection) {
 
 type CertSectionOptions struct {
 	// Size of the generated RSA key in bits.
-	Bits *uci.Int `json:"bits,omitempty"`
+	Bits uci.Optional[uci.Int] `json:"bits,omitzero"`
 	// Common name covered by the certificate.
-	CommonName *string `json:"commonname,omitempty"`
+	CommonName uci.Optional[string] `json:"commonname,omitzero"`
 	// ISO country code of the certificate issuer.
-	Country *string `json:"country,omitempty"`
+	Country uci.Optional[string] `json:"country,omitzero"`
 	// Validity time of the generated certificates in days.
-	Days    *uci.Int `json:"days,omitempty"`
-	ECCurve *string  `json:"ec_curve,omitempty"`
-	KeyType *string  `json:"key_type,omitempty"`
+	Days    uci.Optional[uci.Int] `json:"days,omitzero"`
+	ECCurve uci.Optional[string]  `json:"ec_curve,omitzero"`
+	KeyType uci.Optional[string]  `json:"key_type,omitzero"`
 	// Location/city of the certificate issuer.
-	Location *string `json:"location,omitempty"`
+	Location uci.Optional[string] `json:"location,omitzero"`
 	// Organization name covered by the certificate.
-	Organization *string `json:"organization,omitempty"`
+	Organization uci.Optional[string] `json:"organization,omitzero"`
 	// State of the certificate issuer.
-	State *string `json:"state,omitempty"`
+	State uci.Optional[string] `json:"state,omitzero"`
 }
 
 func (CertSectionOptions) IsConfigSectionOptions() {}
@@ -77,58 +80,66 @@ func (in *UHTTPdSection) DeepCopyInto(out *UHTTPdSection) {
 }
 
 type UHTTPdSectionOptions struct {
+	// List of URL aliases in the form "/alias=/target".
+	Alias uci.Optional[uci.List] `json:"alias,omitzero"`
 	// ASN.1/DER or PEM certificate used to serve HTTPS connections.
-	Cert *string `json:"cert,omitempty"`
+	Cert uci.Optional[string] `json:"cert,omitzero"`
 	// Defines the prefix for CGI scripts, relative to the document root.
-	CGIPrefix *string `json:"cgi_prefix,omitempty"`
+	CGIPrefix uci.Optional[string] `json:"cgi_prefix,omitzero"`
 	// Config file in Busybox httpd format for additional settings.
-	Config *string `json:"config,omitempty"`
+	Config uci.Optional[string] `json:"config,omitzero"`
 	// Virtual URL of file or CGI script to handle 404 request.
-	ErrorPage *string `json:"error_page,omitempty"`
+	ErrorPage uci.Optional[string] `json:"error_page,omitzero"`
 	// Defines the server document root.
-	Home *string `json:"home,omitempty"`
+	Home uci.Optional[string] `json:"home,omitzero"`
 	// Connection reuse: HTTP keepalive.
-	HTTPKeepAlive *uci.Int `json:"http_keepalive,omitempty"`
+	HTTPKeepAlive uci.Optional[uci.Int] `json:"http_keepalive,omitzero"`
 	// Index file(s) to use for directories.
-	IndexFile *string `json:"index_file,omitempty"`
+	IndexFile uci.Optional[string] `json:"index_file,omitzero"`
 	// Index file to use for directories (alternative form).
-	IndexPage *string `json:"index_page,omitempty"`
+	IndexPage uci.Optional[string] `json:"index_page,omitzero"`
+	// List of extension to interpreter mappings for CGI scripts, e.g. ".php=/usr/bin/php-cgi".
+	Interpreter uci.Optional[uci.List] `json:"interpreter,omitzero"`
+	// Paths of JSON handler scripts to load.
+	JSONScript uci.Optional[uci.List] `json:"json_script,omitzero"`
 	// ASN.1/DER or PEM private key used to serve HTTPS connections.
-	Key *string `json:"key,omitempty"`
+	Key uci.Optional[string] `json:"key,omitzero"`
 	// Specifies the ports and addresses to listen on for plain HTTP access.
-	ListenHTTP *uci.List `json:"listen_http,omitempty"`
+	ListenHTTP uci.Optional[uci.List] `json:"listen_http,omitzero"`
 	// Specifies the ports and addresses to listen on for encrypted HTTPS access.
-	ListenHTTPS *uci.List `json:"listen_https,omitempty"`
+	ListenHTTPS uci.Optional[uci.List] `json:"listen_https,omitzero"`
 	// Lua handler script used to initialize the Lua runtime on server start.
-	LuaHandler *string `json:"lua_handler,omitempty"`
+	LuaHandler uci.Optional[string] `json:"lua_handler,omitzero"`
 	// Defines the prefix for dispatching requests to the embedded Lua interpreter.
-	LuaPrefix *uci.List `json:"lua_prefix,omitempty"`
+	LuaPrefix uci.Optional[uci.List] `json:"lua_prefix,omitzero"`
 	// Maximum number of concurrent connections.
-	MaxConnections *uci.Int `json:"max_connections,omitempty"`
+	MaxConnections uci.Optional[uci.Int] `json:"max_connections,omitzero"`
 	// Maximum number of concurrent requests.
-	MaxRequests *uci.Int `json:"max_requests,omitempty"`
+	MaxRequests uci.Optional[uci.Int] `json:"max_requests,omitzero"`
 	// Maximum wait time for network activity in seconds.
-	NetworkTimeout *uci.Int `json:"network_timeout,omitempty"`
+	NetworkTimeout uci.Optional[uci.Int] `json:"network_timeout,omitzero"`
 	// Do not generate directory listings if enabled.
-	NoDirlists *uci.Bool `json:"no_dirlists,omitempty"`
+	NoDirlists uci.Optional[uci.Bool] `json:"no_dirlists,omitzero"`
 	// Do not follow symbolic links if enabled.
-	NoSymlinks *uci.Bool `json:"no_symlinks,omitempty"`
+	NoSymlinks uci.Optional[uci.Bool] `json:"no_symlinks,omitzero"`
 	// Basic authentication realm when prompting the client for credentials.
-	Realm         *string   `json:"realm,omitempty"`
-	RedirectHTTPS *uci.Bool `json:"redirect_https,omitempty"`
+	Realm         uci.Optional[string]   `json:"realm,omitzero"`
+	RedirectHTTPS uci.Optional[uci.Bool] `json:"redirect_https,omitzero"`
 	// Reject requests from RFC1918 IPs to public IPs (DNS rebinding protection).
-	RFC1918Filter *uci.Bool `json:"rfc1918_filter,omitempty"`
+	RFC1918Filter uci.Optional[uci.Bool] `json:"rfc1918_filter,omitzero"`
 	// Maximum wait time for CGI or Lua requests in seconds.
-	ScriptTimeout *uci.Int `json:"script_timeout,omitempty"`
-	TCPKeepAlive  *uci.Int `json:"tcp_keepalive,omitempty"`
+	ScriptTimeout uci.Optional[uci.Int] `json:"script_timeout,omitzero"`
+	TCPKeepAlive  uci.Optional[uci.Int] `json:"tcp_keepalive,omitzero"`
 	// Enable CORS HTTP headers on JSON-RPC API.
-	UbusCors *uci.Bool `json:"ubus_cors,omitempty"`
+	UbusCors uci.Optional[uci.Bool] `json:"ubus_cors,omitzero"`
 	// Do not authenticate JSON-RPC requests against UBUS session API.
-	UbusNoauth *uci.Bool `json:"ubus_noauth,omitempty"`
+	UbusNoauth uci.Optional[uci.Bool] `json:"ubus_noauth,omitzero"`
 	// URL prefix for UBUS via JSON-RPC handler.
-	UbusPrefix *string `json:"ubus_prefix,omitempty"`
+	UbusPrefix uci.Optional[string] `json:"ubus_prefix,omitzero"`
 	// Override ubus socket path.
-	UbusSocket *string `json:"ubus_socket,omitempty"`
+	UbusSocket uci.Optional[string] `json:"ubus_socket,omitzero"`
+	// List of URL prefix to ucode handler script mappings, e.g. "/cgi-bin/luci=/usr/share/ucode/luci/uhttpd.uc".
+	UcodePrefix uci.Optional[uci.List] `json:"ucode_prefix,omitzero"`
 }
 
 func (UHTTPdSectionOptions) IsConfigSectionOptions() {}
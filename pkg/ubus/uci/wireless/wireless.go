@@ -40,6 +40,11 @@ var (
 
 func init() {
 	Sections = []string{WifiDevice, WifiIface, WifiStation, WifiVLAN}
+
+	uci.RegisterSection[WifiDeviceSection, WifiDeviceSectionOptions](Config, WifiDevice)
+	uci.RegisterSection[WifiIfaceSection, WifiIfaceSectionOptions](Config, WifiIface)
+	uci.RegisterSection[WifiStationSection, WifiStationSectionOptions](Config, WifiStation)
+	uci.RegisterSection[WifiVLANSection, WifiVLANSectionOptions](Config, WifiVLAN)
 }
 
 type Channel uint8
@@ -63,12 +68,10 @@ func (c *Channel) UnmarshalJSON(data []byte) (err error) {
 	var val int
 
 	if err = json.Unmarshal(data, &str); err == nil {
-		if val, err = strconv.Atoi(str); err == nil {
-			if str == "auto" {
-				*c = Channel(0)
-			} else {
-				*c = Channel(val)
-			}
+		if str == "auto" {
+			*c = Channel(0)
+		} else if val, err = strconv.Atoi(str); err == nil {
+			*c = Channel(val)
 		}
 	}
 
@@ -87,86 +90,86 @@ func (in *WifiDeviceSection) DeepCopyInto(out *WifiDeviceSection) {
 type WifiDeviceSectionOptions struct {
 	// Set the basic data rates. Each basic_rate is measured in kb/s. This option only has an effect on ap and adhoc wifi-ifaces.
 	// It is recommended to use the cell_density option instead.
-	BasicRate []uci.Int `json:"basic_rate,omitempty"`
+	BasicRate uci.Optional[[]uci.Int] `json:"basic_rate,omitzero"`
 	// Set the beacon interval in units of 1.024 ms. Valid range: 15–65535. Applies only to ap and adhoc wifi-ifaces.
-	BeaconInt *uci.Int `json:"beacon_int,omitempty"`
+	BeaconInt uci.Optional[uci.Int] `json:"beacon_int,omitzero"`
 	// Specifies the band: 2g, 5g, 6g, or 60g. Replaces hwmode (since 21.02.2).
-	Band *string `json:"band,omitempty"`
+	Band uci.Optional[string] `json:"band,omitzero"`
 	// Configures data rates based on coverage cell density. 0 = Disabled, 1 = Normal, 2 = High, 3 = Very High.
-	CellDensity *uci.Int `json:"cell_density,omitempty"`
+	CellDensity uci.Optional[uci.Int] `json:"cell_density,omitzero"`
 	// Specifies a narrow channel width in MHz, e.g. 5, 10, 20.
-	ChanBW *uci.Int `json:"chanbw,omitempty"`
+	ChanBW uci.Optional[uci.Int] `json:"chanbw,omitzero"`
 	// Specifies the wireless channel. “auto” means lowest available or ACS if supported.
-	Channel *Channel `json:"channel,omitempty"` // string or int
+	Channel uci.Optional[Channel] `json:"channel,omitzero"` // string or int
 	// Use specific channels when channel is in “auto” mode.
-	Channels *uci.List `json:"channels,omitempty"`
+	Channels uci.Optional[uci.List] `json:"channels,omitzero"`
 	// Specifies the country code (e.g., "US", "DE"). Affects channel availability and power limits.
-	Country *string `json:"country,omitempty"`
+	Country uci.Optional[string] `json:"country,omitzero"`
 	// Enables 802.11d country IE advertisement. Requires country.
-	CountryIE *uci.Bool `json:"country_ie,omitempty"`
+	CountryIE uci.Optional[uci.Bool] `json:"country_ie,omitzero"`
 	// Distance between the AP and the furthest client in meters.
-	Distance *string `json:"distance,omitempty"`
+	Distance uci.Optional[string] `json:"distance,omitzero"`
 	// Enables or disables the radio adapter. true = disabled.
-	Disabled *uci.Bool `json:"disabled,omitempty"`
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
 	// Enables automatic antenna selection by the driver.
-	Diversity *uci.Bool `json:"diversity,omitempty"`
+	Diversity uci.Optional[uci.Bool] `json:"diversity,omitzero"`
 	// Specifies available HT/VHT/HE capabilities. Autodetected by driver.
-	HTCapab *string `json:"ht_capab,omitempty"`
+	HTCapab uci.Optional[string] `json:"ht_capab,omitzero"`
 	// Specifies the high throughput mode: HT20, HT40, VHT80, HE20, etc.
-	HTMode *string `json:"htmode,omitempty"`
+	HTMode uci.Optional[string] `json:"htmode,omitzero"`
 	// Specifies the hardware mode. Deprecated in favor of Band since 21.02.2.
-	HWMode *string `json:"hwmode,omitempty"`
+	HWMode uci.Optional[string] `json:"hwmode,omitzero"`
 	// Pass any custom options to hostapd-*.conf. Values are passed as-is.
-	HostapdOptions *uci.List `json:"hostapd_options,omitempty"`
+	HostapdOptions uci.Optional[uci.List] `json:"hostapd_options,omitzero"`
 	// Specifies the MAC address of the radio adapter. Used to identify the interface.
-	MACAddr *string `json:"macaddr,omitempty"`
+	MACAddr uci.Optional[string] `json:"macaddr,omitzero"`
 	// Set the log level: 0=Verbose, 1=Debug, 2=Info, 3=Notice, 4=Warning.
-	LogLevel *uci.Int `json:"log_level,omitempty"`
+	LogLevel uci.Optional[uci.Int] `json:"log_level,omitzero"`
 	// Allow legacy 802.11b data rates. 0 = Disallow, 1 = Allow.
-	LegacyRates *uci.Bool `json:"legacy_rates,omitempty"`
+	LegacyRates uci.Optional[uci.Bool] `json:"legacy_rates,omitzero"`
 	// Sets the minimum client capability mode required to connect. Options: n, ac.
-	RequireMode *string `json:"require_mode,omitempty"`
+	RequireMode uci.Optional[string] `json:"require_mode,omitzero"`
 	// Specifies the antenna for receiving. 0 = auto, 1 = antenna 1, 2 = antenna 2, etc.
-	RXAntenna *uci.Int `json:"rxantenna,omitempty"`
+	RXAntenna uci.Optional[uci.Int] `json:"rxantenna,omitzero"`
 	// Set the supported data rates in kb/s. Must be a superset of basic_rate.
-	SupportedRates []uci.Int `json:"supported_rates,omitempty"`
+	SupportedRates uci.Optional[[]uci.Int] `json:"supported_rates,omitzero"`
 	// Radio type: e.g., "mac80211", "broadcom". Usually autodetected.
-	Type *string `json:"type,omitempty"`
+	Type uci.Optional[string] `json:"type,omitzero"`
 	// Specifies the transmission power in dBm. Subject to regulatory limits.
-	TxPower *uci.Int `json:"txpower,omitempty"`
+	TxPower uci.Optional[uci.Int] `json:"txpower,omitzero"`
 	// Specifies the antenna for transmitting. Same values as rxantenna.
-	TXAntenna *uci.Int `json:"txantenna,omitempty"`
+	TXAntenna uci.Optional[uci.Int] `json:"txantenna,omitzero"`
 	// Specifies the radio PHY device. Usually autodetected.
-	PHY *string `json:"phy,omitempty"`
+	PHY uci.Optional[string] `json:"phy,omitzero"`
 
 	//
 	// MAC80211 options
 	//
 
 	// Reduction in antenna gain from regulatory maximum in dBi
-	AntennaGain *uci.Int `json:"antenna_gain,omitempty"`
+	AntennaGain uci.Optional[uci.Int] `json:"antenna_gain,omitzero"`
 	// Fragmentation threshold
-	Frag *uci.Int `json:"frag,omitempty"`
+	Frag uci.Optional[uci.Int] `json:"frag,omitzero"`
 	// Disable honoring 40 MHz intolerance in coexistence flags of stations.
 	// When enabled, the radio will continue using 40 MHz channels even if intolerance is indicated by another AP/station.
-	HTCoex *uci.Int `json:"ht_coex,omitempty"`
+	HTCoex uci.Optional[uci.Int] `json:"ht_coex,omitzero"`
 	// Do not scan for overlapping BSSs in HT40+/- mode. May violate regulatory requirements if enabled.
-	NoScan *uci.Bool `json:"noscan,omitempty"`
+	NoScan uci.Optional[uci.Bool] `json:"noscan,omitzero"`
 	// Alternative to phy used to identify the device based on /sys/devices path
-	Path *string `json:"path,omitempty"`
+	Path uci.Optional[string] `json:"path,omitzero"`
 	// Override the RTS/CTS threshold
-	RTS *uci.Int `json:"rts,omitempty"`
+	RTS uci.Optional[uci.Int] `json:"rts,omitzero"`
 
 	//
 	// Broadcom options
 	//
 
 	// Enables Broadcom frame bursting (Xpress Technology) if supported
-	FrameBurst *uci.Bool `json:"frameburst,omitempty"`
+	FrameBurst uci.Optional[uci.Bool] `json:"frameburst,omitzero"`
 	// Limits the maximum allowed number of associated clients
-	MaxAssoc *uci.Int `json:"maxassoc,omitempty"`
+	MaxAssoc uci.Optional[uci.Int] `json:"maxassoc,omitzero"`
 	// Slot time in milliseconds
-	SlotTime *uci.Int `json:"slottime,omitempty"`
+	SlotTime uci.Optional[uci.Int] `json:"slottime,omitzero"`
 
 	//
 	// Ubiquiti Nanostation options
@@ -174,7 +177,7 @@ type WifiDeviceSectionOptions struct {
 
 	// Specifies the antenna, possible values are 'vertical' for internal vertical polarization, 'horizontal' for internal
 	// horizontal polarization, or 'external' to use the external antenna connector
-	Antenna *string `json:"antenna,omitempty"`
+	Antenna uci.Optional[string] `json:"antenna,omitzero"`
 }
 
 func (WifiDeviceSectionOptions) IsConfigSectionOptions() {}
@@ -190,91 +193,137 @@ func (in *WifiIfaceSection) DeepCopyInto(out *WifiIfaceSection) {
 
 type WifiIfaceSectionOptions struct {
 	// BSSID override (used in adhoc/sta/WDS mode).
-	BSSID *string `json:"bssid,omitempty"`
+	BSSID uci.Optional[string] `json:"bssid,omitzero"`
 	// List of supported basic data rates (in kb/s).
-	BasicRate []uci.Int `json:"basic_rate,omitempty"`
+	BasicRate uci.Optional[[]uci.Int] `json:"basic_rate,omitzero"`
 	// Isolates clients across different radios on the same bridge.
-	BridgeIsolate *uci.Bool `json:"bridge_isolate,omitempty"`
+	BridgeIsolate uci.Optional[uci.Bool] `json:"bridge_isolate,omitzero"`
 	// Delivery traffic indication message period (1–255).
-	DTIMPeriod *uci.Int `json:"dtim_period,omitempty"`
+	DTIMPeriod uci.Optional[uci.Int] `json:"dtim_period,omitzero"`
 	// Disables the wireless network interface.
-	Disabled *uci.Bool `json:"disabled,omitempty"`
+	Disabled uci.Optional[uci.Bool] `json:"disabled,omitzero"`
 	// For STA: disables network block by default.
-	DefaultDisabled *uci.Bool `json:"default_disabled,omitempty"`
+	DefaultDisabled uci.Optional[uci.Bool] `json:"default_disabled,omitzero"`
 	// Specifies the Wi-Fi adapter (must match a wifi-device).
-	Device *string `json:"device,omitempty"`
+	Device uci.Optional[string] `json:"device,omitzero"`
 	// Enables 802.11h support.
-	Doth *uci.Bool `json:"doth,omitempty"`
+	Doth uci.Optional[uci.Bool] `json:"doth,omitzero"`
 	// Sets WPA/WEP encryption method.
-	Encryption *string `json:"encryption,omitempty"`
+	Encryption uci.Optional[string] `json:"encryption,omitzero"`
 	// Custom interface name (max 15 characters).
-	IfName *string `json:"ifname,omitempty"`
+	IfName uci.Optional[string] `json:"ifname,omitzero"`
 	// Enables client-to-client isolation on AP.
-	Isolate *uci.Bool `json:"isolate,omitempty"`
+	Isolate uci.Optional[uci.Bool] `json:"isolate,omitzero"`
 	// Used for 802.11f IAPP.
-	IAPPInterface *string `json:"iapp_interface,omitempty"`
+	IAPPInterface uci.Optional[string] `json:"iapp_interface,omitzero"`
 	// Enables 802.11w (MFP): 0 = disabled, 1 = optional, 2 = required.
-	IEEE80211w *uci.Int `json:"ieee80211w,omitempty"`
+	IEEE80211w uci.Optional[uci.Int] `json:"ieee80211w,omitzero"`
 	// Maximum timeout for 802.11w SA Query (ms).
-	IEEE80211wMaxTimeout *uci.Int `json:"ieee80211w_max_timeout,omitempty"`
+	IEEE80211wMaxTimeout uci.Optional[uci.Int] `json:"ieee80211w_max_timeout,omitzero"`
 	// Retry timeout for 802.11w SA Query (ms).
-	IEEE80211wRetryTimeout *uci.Int `json:"ieee80211w_retry_timeout,omitempty"`
+	IEEE80211wRetryTimeout uci.Optional[uci.Int] `json:"ieee80211w_retry_timeout,omitzero"`
 	// Additional hostapd BSS options.
-	HostapdBSSOptions *uci.List `json:"hostapd_bss_options,omitempty"`
+	HostapdBSSOptions uci.Optional[uci.List] `json:"hostapd_bss_options,omitzero"`
 	// Whether SSID should be hidden from beacon frames.
-	Hidden *uci.Bool `json:"hidden,omitempty"`
+	Hidden uci.Optional[uci.Bool] `json:"hidden,omitzero"`
 	// Sets the maximum STA listen interval allowed.
-	MaxListenInterval *uci.Int `json:"max_listen_int,omitempty"`
+	MaxListenInterval uci.Optional[uci.Int] `json:"max_listen_int,omitzero"`
 	// Maximum number of clients allowed to connect.
-	MaxAssoc *uci.Int `json:"maxassoc,omitempty"`
+	MaxAssoc uci.Optional[uci.Int] `json:"maxassoc,omitzero"`
 	// Override MAC address, or set to "random".
-	MACAddr *string `json:"macaddr,omitempty"`
+	MACAddr uci.Optional[string] `json:"macaddr,omitzero"`
 	// MAC filter policy: disable, allow, deny.
-	MACFilter *string `json:"macfilter,omitempty"`
+	MACFilter uci.Optional[string] `json:"macfilter,omitzero"`
 	// List of MACs for the MAC filter.
-	MACList *uci.List `json:"maclist,omitempty"`
+	MACList uci.Optional[uci.List] `json:"maclist,omitzero"`
 	// Join this mesh ID (IEEE 802.11s).
-	MeshID *string `json:"mesh_id,omitempty"`
+	MeshID uci.Optional[string] `json:"mesh_id,omitzero"`
 	// Multicast rate (in kb/s). Only in mesh/adhoc.
-	McastRate *uci.Int `json:"mcast_rate,omitempty"`
+	McastRate uci.Optional[uci.Int] `json:"mcast_rate,omitzero"`
 	// Operating mode: ap, sta, adhoc, mesh, monitor.
-	Mode *string `json:"mode,omitempty"`
+	Mode uci.Optional[string] `json:"mode,omitzero"`
 	// Logical networks attached (L3 bridge).
-	Network uci.List `json:"network,omitempty"`
+	Network uci.Optional[uci.List] `json:"network,omitzero"`
 	// Opportunistic Wireless Encryption (OWE) BSSID.
-	OWETransitionBSSID *string `json:"owe_transition_bssid,omitempty"`
+	OWETransitionBSSID uci.Optional[string] `json:"owe_transition_bssid,omitzero"`
 	// Opportunistic Wireless Encryption (OWE) SSID.
-	OWETransitionSSID *string `json:"owe_transition_ssid,omitempty"`
+	OWETransitionSSID uci.Optional[string] `json:"owe_transition_ssid,omitzero"`
 	// Operating Channel Validation (OCV) config: 0–2.
-	OCV *uci.Int `json:"ocv,omitempty"`
+	OCV uci.Optional[uci.Int] `json:"ocv,omitzero"`
 	// Wireless passphrase or key (WPA/WEP).
-	Key *string `json:"key,omitempty"`
+	Key uci.Optional[string] `json:"key,omitzero"`
 	// WEP key slot 1.
-	Key1 *string `json:"key1,omitempty"`
+	Key1 uci.Optional[string] `json:"key1,omitzero"`
 	// WEP key slot 2.
-	Key2 *string `json:"key2,omitempty"`
+	Key2 uci.Optional[string] `json:"key2,omitzero"`
 	// WEP key slot 3.
-	Key3 *string `json:"key3,omitempty"`
+	Key3 uci.Optional[string] `json:"key3,omitzero"`
 	// WEP key slot 4.
-	Key4 *string `json:"key4,omitempty"`
+	Key4 uci.Optional[string] `json:"key4,omitzero"`
 	// RSN Preauthentication for WPA2-EAP.
-	RSNPreauth *uci.Bool `json:"rsn_preauth,omitempty"`
+	RSNPreauth uci.Optional[uci.Bool] `json:"rsn_preauth,omitzero"`
 	// Require MFP for SAE associations.
-	SAERequireMFP *uci.Bool `json:"sae_require_mfp,omitempty"`
+	SAERequireMFP uci.Optional[uci.Bool] `json:"sae_require_mfp,omitzero"`
 	// SAE PWE mechanism: 0 = hunting, 1 = hash, 2 = both.
-	SAEPWE *uci.Int `json:"sae_pwe,omitempty"`
+	SAEPWE uci.Optional[uci.Int] `json:"sae_pwe,omitzero"`
 	// SSID to broadcast or connect to.
-	SSID *string `json:"ssid,omitempty"`
+	SSID uci.Optional[string] `json:"ssid,omitzero"`
 	// Start with AP beaconing disabled.
-	StartDisabled *uci.Bool `json:"start_disabled,omitempty"`
+	StartDisabled uci.Optional[uci.Bool] `json:"start_disabled,omitzero"`
 	// Use of short preamble.
-	ShortPreamble *uci.Bool `json:"short_preamble,omitempty"`
+	ShortPreamble uci.Optional[uci.Bool] `json:"short_preamble,omitzero"`
 	// Supported data rates (in kb/s).
-	SupportedRates []uci.Int `json:"supported_rates,omitempty"`
+	SupportedRates uci.Optional[[]uci.Int] `json:"supported_rates,omitzero"`
 	// Enables 4-address mode (WDS).
-	WDS *uci.Bool `json:"wds,omitempty"`
+	WDS uci.Optional[uci.Bool] `json:"wds,omitzero"`
 	// Enables Wi-Fi Multimedia (WMM) QoS mode.
-	WMM *uci.Bool `json:"wmm,omitempty"`
+	WMM uci.Optional[uci.Bool] `json:"wmm,omitzero"`
 }
 
 func (WifiIfaceSectionOptions) IsConfigSectionOptions() {}
+
+type WifiStationSection struct {
+	uci.StaticSectionOptions  `json:",inline"`
+	WifiStationSectionOptions `json:",inline"`
+}
+
+func (in *WifiStationSection) DeepCopyInto(out *WifiStationSection) {
+	*out = *in
+}
+
+// per-station settings used by hostapd, e.g. for multiple PSKs on a single wifi-iface
+type WifiStationSectionOptions struct {
+	// List of wifi-iface section names this station entry applies to. Applies to all if unset.
+	Iface uci.Optional[uci.List] `json:"iface,omitzero"`
+	// Passphrase the station uses to connect.
+	Key uci.Optional[string] `json:"key,omitzero"`
+	// List of MAC addresses of the station(s). If unset, the key is accepted from any station.
+	MAC uci.Optional[uci.List] `json:"mac,omitzero"`
+	// VLAN ID stations connecting with this key are assigned to, see WifiVLANSection.
+	VID uci.Optional[uci.Int] `json:"vid,omitzero"`
+}
+
+func (WifiStationSectionOptions) IsConfigSectionOptions() {}
+
+type WifiVLANSection struct {
+	uci.StaticSectionOptions `json:",inline"`
+	WifiVLANSectionOptions   `json:",inline"`
+}
+
+func (in *WifiVLANSection) DeepCopyInto(out *WifiVLANSection) {
+	*out = *in
+}
+
+// dynamic VLANs which stations are assigned to by a WifiStationSection or RADIUS
+type WifiVLANSectionOptions struct {
+	// List of wifi-iface section names this VLAN applies to. Applies to all if unset.
+	Iface uci.Optional[uci.List] `json:"iface,omitzero"`
+	// Name of the VLAN interface, appended to the wifi-iface's ifname.
+	Name uci.Optional[string] `json:"name,omitzero"`
+	// Logical network the VLAN interface is attached to.
+	Network uci.Optional[string] `json:"network,omitzero"`
+	// VLAN ID.
+	VID uci.Optional[uci.Int] `json:"vid,omitzero"`
+}
+
+func (WifiVLANSectionOptions) IsConfigSectionOptions() {}